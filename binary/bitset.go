@@ -0,0 +1,186 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package binary
+
+import (
+	"math/bits"
+	mrand "math/rand"
+	"strings"
+)
+
+// Bitset represents a packed binary genome where each bit is a gene
+type Bitset struct {
+	data []uint64 // The packed bits
+	size int      // The number of bits
+}
+
+// NewBitset creates a function for a random bitset genome of the specified number of bits
+func NewBitset(size int) func() *Bitset {
+	return func() *Bitset {
+		b := &Bitset{
+			data: make([]uint64, (size+63)>>6),
+			size: size,
+		}
+
+		for i := range b.data {
+			b.data[i] = mrand.Uint64()
+		}
+
+		b.trim()
+		return b
+	}
+}
+
+// Len returns the number of bits in the bitset
+func (b *Bitset) Len() int {
+	return b.size
+}
+
+// Words returns the underlying words of the bitset, where the i-th bit is stored in the
+// (i % 64) bit of the (i / 64) word.
+func (b *Bitset) Words() []uint64 {
+	return b.data
+}
+
+// Get returns whether the i-th bit is set
+func (b *Bitset) Get(i int) bool {
+	return b.data[i>>6]&(1<<(i&63)) != 0
+}
+
+// Set sets or clears the i-th bit
+func (b *Bitset) Set(i int, value bool) {
+	if value {
+		b.data[i>>6] |= 1 << (i & 63)
+		return
+	}
+
+	b.data[i>>6] &^= 1 << (i & 63)
+}
+
+// Flip flips the i-th bit
+func (b *Bitset) Flip(i int) {
+	b.data[i>>6] ^= 1 << (i & 63)
+}
+
+// Count returns the number of bits set
+func (b *Bitset) Count() (count int) {
+	for _, w := range b.data {
+		count += bits.OnesCount64(w)
+	}
+	return
+}
+
+// Hamming returns the number of bits which differ between the two bitsets. If the sizes
+// are different, every bit missing in one of the bitsets is counted as a difference.
+func (b *Bitset) Hamming(other *Bitset) (distance int) {
+	size, diff := b.size, other.size-b.size
+	if diff < 0 {
+		size, diff = other.size, -diff
+	}
+
+	// Compare the whole words first, then the remaining bits of the common size
+	n := size >> 6
+	for i := 0; i < n; i++ {
+		distance += bits.OnesCount64(b.data[i] ^ other.data[i])
+	}
+
+	if tail := size & 63; tail != 0 {
+		distance += bits.OnesCount64((b.data[n] ^ other.data[n]) & ((1 << tail) - 1))
+	}
+	return distance + diff
+}
+
+// Range iterates over the indices of the bits which are set
+func (b *Bitset) Range(fn func(i int)) {
+	for i, w := range b.data {
+		for w != 0 {
+			fn(i<<6 + bits.TrailingZeros64(w))
+			w &= w - 1
+		}
+	}
+}
+
+// Crossover implements a uniform crossover using random word masks. The offspring has the size
+// of the first parent, and the bits missing in the second parent are copied from the first one.
+func (b *Bitset) Crossover(b1, b2 *Bitset) {
+	b.resize(b1.size)
+	copy(b.data, b1.data)
+
+	// Mix the words which are present in both parents, masking out the missing bits
+	limit := b2.size
+	if b1.size < limit {
+		limit = b1.size
+	}
+
+	for i := 0; i<<6 < limit; i++ {
+		mask := mrand.Uint64()
+		if tail := limit - i<<6; tail < 64 {
+			mask |= ^uint64(0) << tail
+		}
+
+		b.data[i] = (b1.data[i] & mask) | (b2.data[i] &^ mask)
+	}
+}
+
+// Mutate flips every bit independently, with a rate of one expected flip per genome
+func (b *Bitset) Mutate() {
+	if b.size == 0 {
+		return
+	}
+
+	// Skip over the bits which are not flipped, following a geometric distribution
+	rate := 1 / float64(b.size)
+	for i := skipBits(rate, b.size); i < b.size; i += 1 + skipBits(rate, b.size) {
+		b.Flip(i)
+	}
+}
+
+// Reset resets the internal state, no-op in this case
+func (b *Bitset) Reset() {
+	// No state
+}
+
+// Clone returns a copy of the bitset
+func (b *Bitset) Clone() *Bitset {
+	return &Bitset{
+		data: append([]uint64(nil), b.data...),
+		size: b.size,
+	}
+}
+
+// String implement stringer interface
+func (b *Bitset) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+
+	var sb strings.Builder
+	sb.Grow(b.size)
+	for i := 0; i < b.size; i++ {
+		if b.Get(i) {
+			sb.WriteByte('1')
+		} else {
+			sb.WriteByte('0')
+		}
+	}
+	return sb.String()
+}
+
+// resize resizes the bitset to the specified number of bits, reusing its capacity if possible
+func (b *Bitset) resize(size int) {
+	n := (size + 63) >> 6
+	if cap(b.data) < n {
+		b.data = make([]uint64, n)
+	}
+
+	b.data = b.data[:n]
+	b.size = size
+}
+
+// trim clears the unused bits of the last word
+func (b *Bitset) trim() {
+	if tail := b.size & 63; tail != 0 {
+		b.data[len(b.data)-1] &= (1 << tail) - 1
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package binary_test
+
+import (
+	"testing"
+
+	"github.com/kelindar/evolve"
+	"github.com/kelindar/evolve/binary"
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+cpu: Intel(R) Xeon(R) Processor
+BenchmarkBitset/evolve         	     272	   4551377 ns/op	      80 B/op	       3 allocs/op
+*/
+func BenchmarkBitset(b *testing.B) {
+	b.Run("evolve", func(b *testing.B) {
+		pop := evolve.New(256, func(g *binary.Bitset) float32 {
+			return float32(g.Count())
+		}, binary.NewBitset(100000))
+
+		b.ResetTimer()
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			pop.Evolve()
+		}
+	})
+}
+
+func TestBitsetEvolve(t *testing.T) {
+	const size = 1000
+	pop := evolve.New(256, func(g *binary.Bitset) float32 {
+		return float32(g.Count())
+	}, binary.NewBitset(size))
+
+	// Evolve
+	var last *binary.Bitset
+	for i := 0; i < 10000; i++ {
+		if last = pop.Evolve(); last.Count() == size {
+			break
+		}
+	}
+
+	assert.Equal(t, size, last.Count())
+}
+
+func TestBitsetBits(t *testing.T) {
+	b := binary.NewBitset(100)()
+	for i := 0; i < b.Len(); i++ {
+		b.Set(i, i%3 == 0)
+	}
+
+	assert.Equal(t, 100, b.Len())
+	assert.Equal(t, 34, b.Count())
+	assert.Len(t, b.Words(), 2)
+	assert.True(t, b.Get(99))
+	assert.False(t, b.Get(98))
+
+	b.Flip(99)
+	b.Flip(98)
+	assert.False(t, b.Get(99))
+	assert.True(t, b.Get(98))
+
+	var indices []int
+	b.Range(func(i int) {
+		indices = append(indices, i)
+	})
+	assert.Len(t, indices, 34)
+	assert.Equal(t, []int{0, 3, 6}, indices[:3])
+	assert.Equal(t, 98, indices[33])
+}
+
+func TestBitsetHamming(t *testing.T) {
+	b1 := binary.NewBitset(130)()
+	b2 := binary.NewBitset(130)()
+	for i := 0; i < 130; i++ {
+		b1.Set(i, false)
+		b2.Set(i, i < 10)
+	}
+
+	assert.Equal(t, 0, b1.Hamming(b1))
+	assert.Equal(t, 10, b1.Hamming(b2))
+	assert.Equal(t, 10, b2.Hamming(b1))
+
+	b3 := binary.NewBitset(110)()
+	for i := 0; i < 110; i++ {
+		b3.Set(i, i < 5)
+	}
+	assert.Equal(t, 25, b1.Hamming(b3))
+	assert.Equal(t, 25, b3.Hamming(b1))
+}
+
+func TestBitsetCrossover(t *testing.T) {
+	b1 := binary.NewBitset(1000)()
+	b2 := binary.NewBitset(1000)()
+	for i := 0; i < 1000; i++ {
+		b1.Set(i, false)
+		b2.Set(i, true)
+	}
+
+	child := binary.NewBitset(10)()
+	child.Crossover(b1, b2)
+	child.Mutate()
+	child.Reset()
+	assert.Equal(t, 1000, child.Len())
+	assert.InDelta(t, 500, child.Count(), 100)
+}
+
+func TestBitsetString(t *testing.T) {
+	b := binary.NewBitset(4)()
+	b.Set(0, true)
+	b.Set(1, false)
+	b.Set(2, true)
+	b.Set(3, false)
+	assert.Equal(t, "1010", b.String())
+
+	var empty *binary.Bitset
+	assert.Equal(t, "<nil>", empty.String())
+}
+
+func TestGenomeHamming(t *testing.T) {
+	g1 := binary.Genome{0x00, 0xff}
+	g2 := binary.Genome{0x0f, 0xff, 0x01}
+	assert.Equal(t, 0, g1.Hamming(&g1))
+	assert.Equal(t, 12, g1.Hamming(&g2))
+	assert.Equal(t, 12, g2.Hamming(&g1))
+}
@@ -0,0 +1,63 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package binary
+
+import (
+	"math"
+)
+
+// Distance returns the hamming distance between the two genomes
+func (g *Genome) Distance(other *Genome) float64 {
+	return float64(g.Hamming(other))
+}
+
+// Distance returns the hamming distance between the two chromosomes
+func (c *Chromosome) Distance(other *Chromosome) float64 {
+	return float64(c.Genome.Hamming(&other.Genome))
+}
+
+// Distance returns the hamming distance between the two bitsets
+func (b *Bitset) Distance(other *Bitset) float64 {
+	return float64(b.Hamming(other))
+}
+
+// Entropy computes the entropy of every bit (locus) across the genomes, in bits. A locus
+// with an entropy of zero has converged, while an entropy of one means that the bit is set
+// in half of the genomes. If the lengths are different, every locus only accounts for the
+// genomes which contain it. A random sample of the population is usually enough.
+func Entropy(genomes ...*Genome) []float64 {
+	length := 0
+	for _, g := range genomes {
+		if len(*g) > length {
+			length = len(*g)
+		}
+	}
+
+	// Count the number of genomes containing each locus, and how many have it set
+	ones := make([]int, length*8)
+	total := make([]int, length*8)
+	for _, g := range genomes {
+		for i := 0; i < len(*g)*8; i++ {
+			total[i]++
+			if g.Bit(i) {
+				ones[i]++
+			}
+		}
+	}
+
+	entropy := make([]float64, length*8)
+	for i := range entropy {
+		entropy[i] = binaryEntropy(float64(ones[i]) / float64(total[i]))
+	}
+	return entropy
+}
+
+// binaryEntropy computes the entropy of a bernoulli distribution, in bits
+func binaryEntropy(p float64) float64 {
+	if p <= 0 || p >= 1 {
+		return 0
+	}
+
+	return -p*math.Log2(p) - (1-p)*math.Log2(1-p)
+}
@@ -0,0 +1,45 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package binary_test
+
+import (
+	"testing"
+
+	"github.com/kelindar/evolve/binary"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistance(t *testing.T) {
+	g1, g2 := binary.Genome{0b1010}, binary.Genome{0b0110, 0xff}
+	assert.Equal(t, 10.0, g1.Distance(&g2))
+
+	b1, b2 := binary.NewBitset(70)(), binary.NewBitset(70)()
+	b1.Set(3, true)
+	b2.Set(3, false)
+	b2.Set(69, !b1.Get(69))
+	assert.Equal(t, float64(b1.Hamming(b2)), b1.Distance(b2))
+
+	c1 := binary.NewChromosome(2, binary.Operators{})()
+	c2 := c1.Clone()
+	assert.Equal(t, 0.0, c1.Distance(c2))
+	c2.Flip(0)
+	assert.Equal(t, 1.0, c1.Distance(c2))
+}
+
+func TestEntropy(t *testing.T) {
+	entropy := binary.Entropy(
+		&binary.Genome{0b0001},
+		&binary.Genome{0b0011},
+		&binary.Genome{0b0101, 0xff},
+		&binary.Genome{0b0111},
+	)
+
+	assert.Len(t, entropy, 16)
+	assert.Equal(t, 0.0, entropy[0]) // always set
+	assert.Equal(t, 1.0, entropy[1]) // set in half of the genomes
+	assert.Equal(t, 1.0, entropy[2]) // set in half of the genomes
+	assert.Equal(t, 0.0, entropy[3]) // never set
+	assert.Equal(t, 0.0, entropy[8]) // only present in a single genome
+	assert.Empty(t, binary.Entropy())
+}
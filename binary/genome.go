@@ -5,28 +5,30 @@ package binary
 
 import (
 	crand "crypto/rand"
+	"math/bits"
 	mrand "math/rand"
-
-	"github.com/kelindar/evolve"
 )
 
 // Genome represents a binary genome
 type Genome []byte
 
-// Crossover implements a random binary crossover
-func (g *Genome) Crossover(p1, p2 evolve.Genome) {
-	v1, v2 := *p1.(*Genome), *p2.(*Genome)
-	n := len(v1)
+// Crossover implements a random binary crossover. The offspring has the length of the first
+// parent, and the genes which are missing in the second parent are copied from the first one.
+func (g *Genome) Crossover(p1, p2 *Genome) {
+	v1, v2 := *p1, *p2
+	resize(g, len(v1))
+	n := overlap(v1, v2)
 	for i := 0; i < n; i++ {
 		r := randByte()
 		(*g)[i] = (v1[i] & byte(r)) ^ (v2[i] & (^byte(r)))
 	}
+	copy((*g)[n:], v1[n:])
 }
 
 // Mutate mutates a random gene
 func (g *Genome) Mutate() {
 	const rate = 0.01
-	if mrand.Float32() >= rate {
+	if len(*g) == 0 || mrand.Float32() >= rate {
 		return
 	}
 
@@ -43,11 +45,51 @@ func (g *Genome) String() string {
 	return string(*g)
 }
 
+// Bit returns whether the i-th bit of the genome is set
+func (g *Genome) Bit(i int) bool {
+	return (*g)[i>>3]&(1<<(i&7)) != 0
+}
+
+// SetBit sets or clears the i-th bit of the genome
+func (g *Genome) SetBit(i int, value bool) {
+	if value {
+		(*g)[i>>3] |= 1 << (i & 7)
+		return
+	}
+
+	(*g)[i>>3] &^= 1 << (i & 7)
+}
+
+// Flip flips the i-th bit of the genome
+func (g *Genome) Flip(i int) {
+	(*g)[i>>3] ^= 1 << (i & 7)
+}
+
+// Hamming returns the number of bits which differ between the two genomes. If the lengths
+// are different, every bit missing in one of the genomes is counted as a difference.
+func (g *Genome) Hamming(other *Genome) (distance int) {
+	v1, v2 := *g, *other
+	if len(v1) > len(v2) {
+		v1, v2 = v2, v1
+	}
+
+	for i, v := range v1 {
+		distance += bits.OnesCount8(v ^ v2[i])
+	}
+	return distance + (len(v2)-len(v1))*8
+}
+
 // Reset resets the internal state, no-op in this case
 func (g *Genome) Reset() {
 	// No state
 }
 
+// Clone returns a copy of the genome
+func (g *Genome) Clone() *Genome {
+	clone := append(Genome(nil), *g...)
+	return &clone
+}
+
 // New creates a function for a random genome string
 func New(length int) func() *Genome {
 	return func() *Genome {
@@ -57,6 +99,14 @@ func New(length int) func() *Genome {
 	}
 }
 
+// overlap returns the number of genes present in both genomes
+func overlap(v1, v2 Genome) int {
+	if len(v2) < len(v1) {
+		return len(v2)
+	}
+	return len(v1)
+}
+
 // randByte generates a random byte
 func randByte() byte {
 	return byte(mrand.Int31n(256))
@@ -11,6 +11,26 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestClone(t *testing.T) {
+	g := binary.New(10)()
+	clone := g.Clone()
+	assert.Equal(t, g, clone)
+	(*clone)[0]++
+	assert.NotEqual(t, g, clone)
+
+	c := binary.NewChromosome(10, binary.Operators{})()
+	cc := c.Clone()
+	assert.Equal(t, c, cc)
+	cc.Genome[0]++
+	assert.NotEqual(t, c, cc)
+
+	b := binary.NewBitset(100)()
+	bc := b.Clone()
+	assert.Equal(t, b, bc)
+	bc.Flip(0)
+	assert.Equal(t, 1, b.Hamming(bc))
+}
+
 func TestEvolve(t *testing.T) {
 	const target = "abc"
 	const n = 200
@@ -0,0 +1,299 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package binary
+
+import (
+	crand "crypto/rand"
+	"math"
+	mrand "math/rand"
+	"sort"
+
+	"github.com/kelindar/evolve/internal/operator"
+	"github.com/kelindar/evolve/internal/sequence"
+)
+
+// Crossover represents a crossover operator which writes the offspring of two parents into dst
+type Crossover func(dst, p1, p2 *Genome)
+
+// Mutation represents a mutation operator which mutates the genome in-place
+type Mutation func(g *Genome)
+
+// Operators represents a set of genetic operators for a binary genome. When one of the
+// operators is not specified, the default byte-level operator of the genome is used.
+type Operators struct {
+	Crossover Crossover // The crossover operator
+	Mutation  Mutation  // The mutation operator
+}
+
+// ---------------------------------- Chromosome ----------------------------------
+
+// Chromosome represents a binary genome with configurable bit-level operators
+type Chromosome struct {
+	Genome
+	ops   *Operators
+	trace []string // The names of the operators, shared between the chromosomes
+}
+
+// NewChromosome creates a function for a random chromosome with the specified operators
+func NewChromosome(length int, ops Operators) func() *Chromosome {
+	trace := []string{operator.Name(ops.Crossover, "crossover"), operator.Name(ops.Mutation, "mutation")}
+	return func() *Chromosome {
+		v := make(Genome, length)
+		crand.Read(v)
+		return &Chromosome{
+			Genome: v,
+			ops:    &ops,
+			trace:  trace,
+		}
+	}
+}
+
+// Crossover performs the crossover using the configured operator
+func (c *Chromosome) Crossover(c1, c2 *Chromosome) {
+	if c.ops.Crossover == nil {
+		c.Genome.Crossover(&c1.Genome, &c2.Genome)
+		return
+	}
+
+	c.ops.Crossover(&c.Genome, &c1.Genome, &c2.Genome)
+}
+
+// Mutate performs the mutation using the configured operator
+func (c *Chromosome) Mutate() {
+	if c.ops.Mutation == nil {
+		c.Genome.Mutate()
+		return
+	}
+
+	c.ops.Mutation(&c.Genome)
+}
+
+// Operators returns the names of the crossover and mutation operators applied to the
+// chromosome, which are recorded in the lineage of the population.
+func (c *Chromosome) Operators() []string {
+	return c.trace
+}
+
+// Reset resets the internal state, no-op in this case
+func (c *Chromosome) Reset() {
+	// No state
+}
+
+// Clone returns a copy of the chromosome, sharing the same operators
+func (c *Chromosome) Clone() *Chromosome {
+	return &Chromosome{
+		Genome: *c.Genome.Clone(),
+		ops:    c.ops,
+		trace:  c.trace,
+	}
+}
+
+// String implement stringer interface
+func (c *Chromosome) String() string {
+	if c == nil {
+		return "<nil>"
+	}
+
+	return c.Genome.String()
+}
+
+// ---------------------------------- Crossover ----------------------------------
+
+// OnePoint creates a crossover which takes the bits before a random cut point from the
+// first parent and the remaining bits from the second parent.
+func OnePoint() Crossover {
+	return NPoint(1)
+}
+
+// TwoPoint creates a crossover which takes the bits between two random cut points from
+// the second parent and the remaining bits from the first parent.
+func TwoPoint() Crossover {
+	return NPoint(2)
+}
+
+// NPoint creates a crossover with n random cut points, alternating the parent the bits
+// are taken from at every cut point. The bits missing in the second parent are taken from
+// the first parent. The number of cut points is clamped to the number of bits minus one,
+// and a non-positive n copies the first parent.
+func NPoint(n int) Crossover {
+	return func(dst, p1, p2 *Genome) {
+		size := len(*p1) * 8
+		cuts := n
+		switch {
+		case size == 0 || cuts < 0:
+			cuts = 0
+		case cuts > size-1:
+			cuts = size - 1
+		}
+
+		// Start with the first parent and select the cut points
+		resize(dst, len(*p1))
+		copy(*dst, *p1)
+		points := randPoints(size, cuts)
+
+		// Every odd segment is taken from the second parent
+		limit := overlap(*p1, *p2) * 8
+		for i := 0; i < len(points) && points[i] < limit; i += 2 {
+			end := limit
+			if i+1 < len(points) && points[i+1] < limit {
+				end = points[i+1]
+			}
+
+			copyBits(*dst, *p2, points[i], end)
+		}
+	}
+}
+
+// Uniform creates a crossover which takes every bit from the first parent with the probability
+// specified by the bias, and from the second parent otherwise. A bias of 0.5 is unbiased.
+func Uniform(bias float64) Crossover {
+	return func(dst, p1, p2 *Genome) {
+		v1, v2 := *p1, *p2
+		resize(dst, len(v1))
+		n := overlap(v1, v2)
+		for i := 0; i < n; i++ {
+			mask := randMask(bias)
+			(*dst)[i] = (v1[i] & mask) | (v2[i] &^ mask)
+		}
+		copy((*dst)[n:], v1[n:])
+	}
+}
+
+// Splice creates a cut-and-splice crossover for variable-length genomes, which joins the head
+// of the first parent with the tail of the second parent at independent cut points. The length
+// of the offspring is kept within the [min, max] range, where a maximum of zero is unbounded.
+func Splice(min, max int) Crossover {
+	return func(dst, p1, p2 *Genome) {
+		*dst = sequence.Splice(*dst, *p1, *p2, min, max)
+	}
+}
+
+// ---------------------------------- Mutation ----------------------------------
+
+// BitFlip creates a mutation which flips every bit independently with the specified rate.
+func BitFlip(rate float64) Mutation {
+	return func(g *Genome) {
+		size := len(*g) * 8
+		switch {
+		case rate <= 0:
+			return
+		case rate >= 1:
+			for i := range *g {
+				(*g)[i] = ^(*g)[i]
+			}
+			return
+		}
+
+		// Skip over the bits which are not flipped, following a geometric distribution
+		for i := skipBits(rate, size); i < size; i += 1 + skipBits(rate, size) {
+			g.Flip(i)
+		}
+	}
+}
+
+// Insertion creates a mutation for variable-length genomes, which inserts a random gene at a
+// random position with the specified rate, as long as the length stays under the maximum. A
+// maximum of zero is unbounded.
+func Insertion(rate float64, max int) Mutation {
+	return func(g *Genome) {
+		if (max > 0 && len(*g) >= max) || mrand.Float64() >= rate {
+			return
+		}
+
+		*g = sequence.Insert(*g, mrand.Intn(len(*g)+1), randByte())
+	}
+}
+
+// Deletion creates a mutation for variable-length genomes, which deletes a random gene with
+// the specified rate, as long as the length stays above the minimum.
+func Deletion(rate float64, min int) Mutation {
+	return func(g *Genome) {
+		if len(*g) <= min || len(*g) == 0 || mrand.Float64() >= rate {
+			return
+		}
+
+		*g = sequence.Delete(*g, mrand.Intn(len(*g)))
+	}
+}
+
+// Chain creates a mutation which applies all of the specified mutations in order
+func Chain(mutations ...Mutation) Mutation {
+	return func(g *Genome) {
+		for _, mutate := range mutations {
+			mutate(g)
+		}
+	}
+}
+
+// ---------------------------------- Helpers ----------------------------------
+
+// resize resizes the genome to the specified length, reusing its capacity if possible
+func resize(g *Genome, length int) {
+	*g = sequence.Resize(*g, length)
+}
+
+// copyBits copies the bits in [from, to) range from the source into the destination
+func copyBits(dst, src Genome, from, to int) {
+	for from < to && from&7 != 0 {
+		dst.SetBit(from, src.Bit(from))
+		from++
+	}
+
+	// Copy the whole bytes at once
+	if n := (to - from) >> 3; n > 0 {
+		copy(dst[from>>3:], src[from>>3:(from>>3)+n])
+		from += n << 3
+	}
+
+	for ; from < to; from++ {
+		dst.SetBit(from, src.Bit(from))
+	}
+}
+
+// randPoints returns n distinct and sorted random cut points in the (0, size) range
+func randPoints(size, n int) []int {
+	points := make([]int, 0, n)
+	for len(points) < n {
+		p := 1 + mrand.Intn(size-1)
+		if !contains(points, p) {
+			points = append(points, p)
+		}
+	}
+
+	sort.Ints(points)
+	return points
+}
+
+// contains checks whether the value is present in the slice
+func contains(values []int, v int) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// randMask generates a random byte where every bit is set with the specified probability
+func randMask(bias float64) (mask byte) {
+	if bias == 0.5 {
+		return randByte()
+	}
+
+	for i := 0; i < 8; i++ {
+		if mrand.Float64() < bias {
+			mask |= 1 << i
+		}
+	}
+	return
+}
+
+// skipBits returns the number of bits to skip until the next bit flip, up to a limit
+func skipBits(rate float64, limit int) int {
+	skip := math.Log(1-mrand.Float64()) / math.Log(1-rate)
+	if skip > float64(limit) {
+		return limit
+	}
+	return int(skip)
+}
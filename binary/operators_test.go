@@ -0,0 +1,237 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package binary_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kelindar/evolve"
+	"github.com/kelindar/evolve/binary"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNPoint(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 5, 10} {
+		for i := 0; i < 100; i++ {
+			dst, p1, p2 := parentsOf(64)
+			binary.NPoint(n)(dst, p1, p2)
+			assert.Equal(t, n, transitionsOf(dst))
+			assert.False(t, dst.Bit(0))
+		}
+	}
+}
+
+func TestOnePoint(t *testing.T) {
+	dst, p1, p2 := parentsOf(3)
+	binary.OnePoint()(dst, p1, p2)
+	assert.Equal(t, 1, transitionsOf(dst))
+	assert.True(t, dst.Bit(23))
+}
+
+func TestTwoPoint(t *testing.T) {
+	dst, p1, p2 := parentsOf(3)
+	binary.TwoPoint()(dst, p1, p2)
+	assert.Equal(t, 2, transitionsOf(dst))
+	assert.False(t, dst.Bit(0))
+	assert.False(t, dst.Bit(23))
+}
+
+func TestNPointSmall(t *testing.T) {
+	dst, p1, p2 := parentsOf(1)
+	binary.NPoint(100)(dst, p1, p2)
+	assert.Equal(t, 7, transitionsOf(dst))
+}
+
+func TestNPointNone(t *testing.T) {
+	for _, n := range []int{0, -1, -10} {
+		dst, p1, p2 := parentsOf(8)
+		binary.NPoint(n)(dst, p1, p2)
+		assert.Equal(t, *p1, *dst)
+	}
+}
+
+func TestUniform(t *testing.T) {
+	dst, p1, p2 := parentsOf(100)
+	binary.Uniform(1)(dst, p1, p2)
+	assert.Equal(t, *p1, *dst)
+
+	binary.Uniform(0)(dst, p1, p2)
+	assert.Equal(t, *p2, *dst)
+
+	binary.Uniform(0.25)(dst, p1, p2)
+	assert.InDelta(t, 600, onesOf(dst), 100)
+}
+
+func TestBitFlip(t *testing.T) {
+	g := make(binary.Genome, 10000)
+	binary.BitFlip(0)(&g)
+	assert.Equal(t, 0, onesOf(&g))
+
+	binary.BitFlip(1)(&g)
+	assert.Equal(t, 80000, onesOf(&g))
+
+	g = make(binary.Genome, 10000)
+	binary.BitFlip(0.1)(&g)
+	assert.InDelta(t, 8000, onesOf(&g), 500)
+}
+
+func TestBits(t *testing.T) {
+	g := make(binary.Genome, 2)
+	g.SetBit(9, true)
+	assert.True(t, g.Bit(9))
+	assert.Equal(t, binary.Genome{0, 2}, g)
+
+	g.Flip(0)
+	g.SetBit(9, false)
+	assert.Equal(t, binary.Genome{1, 0}, g)
+}
+
+func TestChromosome(t *testing.T) {
+	const target = "abc"
+	match := []byte(target)
+	pop := evolve.New(200, func(c *binary.Chromosome) float32 {
+		var score float32
+		for i := range c.Genome {
+			for b := 0; b < 8; b++ {
+				if c.Bit(i*8+b) == (match[i]&(1<<b) != 0) {
+					score++
+				}
+			}
+		}
+		return score
+	}, binary.NewChromosome(len(target), binary.Operators{
+		Crossover: binary.TwoPoint(),
+		Mutation:  binary.BitFlip(0.01),
+	}))
+
+	// Evolve
+	var last *binary.Chromosome
+	for i := 0; i < 10000; i++ {
+		if last = pop.Evolve(); last.String() == target {
+			break
+		}
+	}
+
+	assert.Equal(t, target, last.String())
+}
+
+func TestChromosomeDefault(t *testing.T) {
+	c := binary.NewChromosome(4, binary.Operators{})()
+	c.Crossover(c, binary.NewChromosome(4, binary.Operators{})())
+	c.Mutate()
+	c.Reset()
+	assert.Len(t, c.Genome, 4)
+}
+
+// parentsOf returns an empty offspring, a parent with all bits cleared and a
+// parent with all bits set.
+func parentsOf(length int) (*binary.Genome, *binary.Genome, *binary.Genome) {
+	dst := make(binary.Genome, 0)
+	p1 := make(binary.Genome, length)
+	p2 := binary.Genome(bytes.Repeat([]byte{0xff}, length))
+	return &dst, &p1, &p2
+}
+
+// transitionsOf counts the number of bit changes in the genome
+func transitionsOf(g *binary.Genome) (count int) {
+	for i := 1; i < len(*g)*8; i++ {
+		if g.Bit(i) != g.Bit(i-1) {
+			count++
+		}
+	}
+	return
+}
+
+// onesOf counts the number of bits set
+func onesOf(g *binary.Genome) (count int) {
+	for i := 0; i < len(*g)*8; i++ {
+		if g.Bit(i) {
+			count++
+		}
+	}
+	return
+}
+
+func TestDifferentLengths(t *testing.T) {
+	operators := map[string]binary.Crossover{
+		"default": func(dst, p1, p2 *binary.Genome) { dst.Crossover(p1, p2) },
+		"npoint":  binary.NPoint(5),
+		"uniform": binary.Uniform(0.5),
+	}
+
+	for name, crossover := range operators {
+		t.Run(name, func(t *testing.T) {
+			long := binary.Genome(bytes.Repeat([]byte{0xff}, 20))
+			short := binary.Genome{0xff, 0xff}
+			dst := make(binary.Genome, 5)
+
+			crossover(&dst, &long, &short)
+			assert.Equal(t, long, dst)
+
+			crossover(&dst, &short, &long)
+			assert.Equal(t, short, dst)
+		})
+	}
+}
+
+func TestSplice(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		dst, p1, p2 := parentsOf(10)
+		*p2 = append(*p2, 0xff, 0xff)
+		binary.Splice(5, 15)(dst, p1, p2)
+		assert.GreaterOrEqual(t, len(*dst), 5)
+		assert.LessOrEqual(t, len(*dst), 15)
+		assert.LessOrEqual(t, transitionsOf(dst), 1)
+	}
+}
+
+func TestInsertionDeletion(t *testing.T) {
+	g := binary.Genome{1, 2, 3}
+	binary.Chain(binary.Insertion(1, 5), binary.Insertion(1, 5), binary.Insertion(1, 5))(&g)
+	assert.Len(t, g, 5)
+
+	binary.Chain(binary.Deletion(1, 2), binary.Deletion(1, 2), binary.Deletion(1, 2), binary.Deletion(1, 2))(&g)
+	assert.Len(t, g, 2)
+
+	binary.Insertion(0, 5)(&g)
+	binary.Deletion(0, 0)(&g)
+	assert.Len(t, g, 2)
+
+	// A maximum of zero is unbounded
+	binary.Insertion(1, 0)(&g)
+	assert.Len(t, g, 3)
+}
+
+func TestVariableLength(t *testing.T) {
+	const target = "hello"
+	pop := evolve.New(256, func(c *binary.Chromosome) float32 {
+		score := float32(len(target) * 2)
+		for i := 0; i < len(c.Genome) || i < len(target); i++ {
+			if i >= len(c.Genome) || i >= len(target) || c.Genome[i] != target[i] {
+				score--
+			}
+		}
+		if score < 0 {
+			return 0
+		}
+		return score
+	}, binary.NewChromosome(2, binary.Operators{
+		Crossover: binary.Splice(1, 10),
+		Mutation: binary.Chain(
+			binary.BitFlip(0.01),
+			binary.Insertion(0.05, 10),
+			binary.Deletion(0.05, 1),
+		),
+	}))
+
+	var last *binary.Chromosome
+	for i := 0; i < 5000; i++ {
+		if last = pop.Evolve(); last.String() == target {
+			break
+		}
+	}
+
+	assert.Equal(t, target, last.String())
+}
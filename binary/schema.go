@@ -0,0 +1,324 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package binary
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"reflect"
+	"strings"
+)
+
+// fieldKind represents the kind of a schema field
+type fieldKind uint8
+
+const (
+	kindInt fieldKind = iota
+	kindReal
+	kindEnum
+	kindBool
+)
+
+// Field represents a named field of a schema, encoded as a segment of bits
+type Field struct {
+	Name   string    // The name of the field
+	kind   fieldKind // The kind of the field
+	size   int       // The number of bits
+	offset int       // The offset of the first bit
+	gray   bool      // Whether gray coding is used
+	min    float64   // The minimum value
+	max    float64   // The maximum value
+	values []string  // The values of an enum
+}
+
+// Int creates an integer field in the [min, max] range
+func Int(name string, min, max int) Field {
+	if max < min {
+		panic(fmt.Errorf("binary: invalid range [%d, %d] for field %s", min, max, name))
+	}
+
+	return Field{
+		Name: name,
+		kind: kindInt,
+		size: bitsFor(uint64(max - min)),
+		min:  float64(min),
+		max:  float64(max),
+	}
+}
+
+// Real creates a fixed-point real field in the [min, max] range with the specified precision in bits
+func Real(name string, min, max float64, precision int) Field {
+	if max < min || precision < 1 || precision > 52 {
+		panic(fmt.Errorf("binary: invalid real field %s", name))
+	}
+
+	return Field{
+		Name: name,
+		kind: kindReal,
+		size: precision,
+		min:  min,
+		max:  max,
+	}
+}
+
+// Enum creates a field which takes one of the specified values
+func Enum(name string, values ...string) Field {
+	if len(values) == 0 {
+		panic(fmt.Errorf("binary: no values for enum field %s", name))
+	}
+
+	return Field{
+		Name:   name,
+		kind:   kindEnum,
+		size:   bitsFor(uint64(len(values) - 1)),
+		max:    float64(len(values) - 1),
+		values: values,
+	}
+}
+
+// Bool creates a boolean field, encoded as a single bit
+func Bool(name string) Field {
+	return Field{
+		Name: name,
+		kind: kindBool,
+		size: 1,
+		max:  1,
+	}
+}
+
+// Gray returns a copy of the field which is encoded using a reflected binary (gray) code, so
+// that adjacent values differ by a single bit and mutations avoid hamming cliffs.
+func (f Field) Gray() Field {
+	f.gray = true
+	return f
+}
+
+// Bits returns the number of bits used to encode the field
+func (f *Field) Bits() int {
+	return f.size
+}
+
+// decode decodes the value of the field as a number
+func (f *Field) decode(g *Genome) float64 {
+	var raw uint64
+	for i := 0; i < f.size; i++ {
+		if g.Bit(f.offset + i) {
+			raw |= 1 << i
+		}
+	}
+
+	if f.gray {
+		raw = GrayDecode(raw)
+	}
+
+	switch f.kind {
+	case kindReal:
+		return f.min + (f.max-f.min)*float64(raw)/float64(maxRaw(f.size))
+	default:
+		// The codes beyond the range wrap around, so that every value has a code
+		return f.min + float64(raw%(uint64(f.max-f.min)+1))
+	}
+}
+
+// encode encodes the number as the value of the field
+func (f *Field) encode(g *Genome, value float64) {
+	var raw uint64
+	value = math.Max(f.min, math.Min(f.max, value)) - f.min
+	switch {
+	case f.kind != kindReal:
+		raw = uint64(math.Round(value))
+	case f.max > f.min:
+		raw = uint64(math.Round(value / (f.max - f.min) * float64(maxRaw(f.size))))
+	}
+
+	if f.gray {
+		raw = GrayEncode(raw)
+	}
+
+	for i := 0; i < f.size; i++ {
+		g.SetBit(f.offset+i, raw&(1<<i) != 0)
+	}
+}
+
+// ---------------------------------- Schema ----------------------------------
+
+// Schema represents a mapping of named fields onto the bits of a binary genome
+type Schema struct {
+	fields []Field
+	size   int
+}
+
+// NewSchema creates a new schema, laying out the fields one after another
+func NewSchema(fields ...Field) *Schema {
+	s := &Schema{fields: make([]Field, 0, len(fields))}
+	for _, f := range fields {
+		f.offset = s.size
+		s.size += f.size
+		s.fields = append(s.fields, f)
+	}
+	return s
+}
+
+// Bits returns the number of bits required to encode the schema
+func (s *Schema) Bits() int {
+	return s.size
+}
+
+// Len returns the number of bytes required to encode the schema
+func (s *Schema) Len() int {
+	return (s.size + 7) >> 3
+}
+
+// New creates a function for a random genome which fits the schema
+func (s *Schema) New() func() *Genome {
+	return New(s.Len())
+}
+
+// Decode decodes the genome into the destination struct. Schema fields are matched with the
+// struct fields by their `binary` tag or by their name, and fields which are missing in the
+// struct are ignored. Enums can be decoded into a string or into an integer index.
+func (s *Schema) Decode(g *Genome, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("binary: decode destination must be a pointer to a struct, got %T", dst)
+	}
+
+	if len(*g)*8 < s.size {
+		return fmt.Errorf("binary: genome of %d bits is too short for the schema of %d bits", len(*g)*8, s.size)
+	}
+
+	for i := range s.fields {
+		f := &s.fields[i]
+		v, ok := fieldOf(rv.Elem(), f.Name)
+		if !ok {
+			continue
+		}
+
+		if err := f.set(v, f.decode(g)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Encode encodes the source struct into the genome, resizing the genome if necessary. This
+// is the inverse of Decode and is useful for seeding a population with known values.
+func (s *Schema) Encode(dst *Genome, src any) error {
+	rv := reflect.Indirect(reflect.ValueOf(src))
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("binary: encode source must be a struct, got %T", src)
+	}
+
+	if len(*dst) < s.Len() {
+		resize(dst, s.Len())
+	}
+
+	for i := range s.fields {
+		f := &s.fields[i]
+		v, ok := fieldOf(rv, f.Name)
+		if !ok {
+			continue
+		}
+
+		value, err := f.get(v)
+		if err != nil {
+			return err
+		}
+
+		f.encode(dst, value)
+	}
+	return nil
+}
+
+// set sets the decoded value into the struct field
+func (f *Field) set(v reflect.Value, value float64) error {
+	switch k := v.Kind(); {
+	case f.kind == kindBool && k == reflect.Bool:
+		v.SetBool(value != 0)
+	case f.kind == kindEnum && k == reflect.String:
+		v.SetString(f.values[int(value)])
+	case f.kind != kindBool && v.CanInt():
+		v.SetInt(int64(value))
+	case f.kind != kindBool && v.CanUint() && value >= 0:
+		v.SetUint(uint64(value))
+	case f.kind != kindBool && v.CanFloat():
+		v.SetFloat(value)
+	default:
+		return fmt.Errorf("binary: unable to decode field %s into %s", f.Name, v.Type())
+	}
+	return nil
+}
+
+// get gets the value to encode from the struct field
+func (f *Field) get(v reflect.Value) (float64, error) {
+	switch k := v.Kind(); {
+	case f.kind == kindBool && k == reflect.Bool:
+		if v.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	case f.kind == kindEnum && k == reflect.String:
+		for i, value := range f.values {
+			if value == v.String() {
+				return float64(i), nil
+			}
+		}
+		return 0, fmt.Errorf("binary: unknown value %q for enum field %s", v.String(), f.Name)
+	case f.kind != kindBool && v.CanInt():
+		return float64(v.Int()), nil
+	case f.kind != kindBool && v.CanUint():
+		return float64(v.Uint()), nil
+	case f.kind != kindBool && v.CanFloat():
+		return v.Float(), nil
+	default:
+		return 0, fmt.Errorf("binary: unable to encode field %s from %s", f.Name, v.Type())
+	}
+}
+
+// fieldOf finds the struct field by its tag or by its name
+func fieldOf(rv reflect.Value, name string) (reflect.Value, bool) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		if sf := rt.Field(i); sf.IsExported() && sf.Tag.Get("binary") == name {
+			return rv.Field(i), true
+		}
+	}
+
+	for i := 0; i < rt.NumField(); i++ {
+		if sf := rt.Field(i); sf.IsExported() && strings.EqualFold(sf.Name, name) {
+			return rv.Field(i), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// ---------------------------------- Gray Code ----------------------------------
+
+// GrayEncode converts a binary number into its reflected binary (gray) code
+func GrayEncode(v uint64) uint64 {
+	return v ^ (v >> 1)
+}
+
+// GrayDecode converts a reflected binary (gray) code into a binary number
+func GrayDecode(v uint64) uint64 {
+	for shift := 1; shift < 64; shift <<= 1 {
+		v ^= v >> shift
+	}
+	return v
+}
+
+// bitsFor returns the number of bits required to represent the number
+func bitsFor(v uint64) int {
+	if v == 0 {
+		return 1
+	}
+	return bits.Len64(v)
+}
+
+// maxRaw returns the maximum raw value representable with the specified number of bits
+func maxRaw(size int) uint64 {
+	return (1 << size) - 1
+}
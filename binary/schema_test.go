@@ -0,0 +1,153 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package binary_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kelindar/evolve"
+	"github.com/kelindar/evolve/binary"
+	"github.com/stretchr/testify/assert"
+)
+
+type params struct {
+	Layers     int
+	Rate       float64 `binary:"learning_rate"`
+	Activation string
+	Optimizer  uint8 `binary:"optimizer"`
+	Dropout    bool
+	Ignored    string
+}
+
+func newSchema() *binary.Schema {
+	return binary.NewSchema(
+		binary.Int("layers", 1, 10).Gray(),
+		binary.Real("learning_rate", 0.0001, 0.1, 16),
+		binary.Enum("activation", "relu", "tanh", "sigmoid"),
+		binary.Enum("optimizer", "sgd", "adam").Gray(),
+		binary.Bool("dropout"),
+	)
+}
+
+func TestSchemaLayout(t *testing.T) {
+	s := newSchema()
+	assert.Equal(t, 4+16+2+1+1, s.Bits())
+	assert.Equal(t, 3, s.Len())
+	assert.Len(t, *s.New()(), 3)
+}
+
+func TestSchemaRoundtrip(t *testing.T) {
+	s := newSchema()
+	input := params{
+		Layers:     7,
+		Rate:       0.05,
+		Activation: "sigmoid",
+		Optimizer:  1,
+		Dropout:    true,
+	}
+
+	var g binary.Genome
+	assert.NoError(t, s.Encode(&g, input))
+	assert.Len(t, g, 3)
+
+	var output params
+	assert.NoError(t, s.Decode(&g, &output))
+	assert.Equal(t, input.Layers, output.Layers)
+	assert.InDelta(t, input.Rate, output.Rate, 0.0001)
+	assert.Equal(t, input.Activation, output.Activation)
+	assert.Equal(t, input.Optimizer, output.Optimizer)
+	assert.Equal(t, input.Dropout, output.Dropout)
+}
+
+func TestSchemaDecodeRandom(t *testing.T) {
+	s := newSchema()
+	for i := 0; i < 1000; i++ {
+		var out params
+		assert.NoError(t, s.Decode(s.New()(), &out))
+		assert.GreaterOrEqual(t, out.Layers, 1)
+		assert.LessOrEqual(t, out.Layers, 10)
+		assert.GreaterOrEqual(t, out.Rate, 0.0001)
+		assert.LessOrEqual(t, out.Rate, 0.1)
+		assert.Contains(t, []string{"relu", "tanh", "sigmoid"}, out.Activation)
+		assert.LessOrEqual(t, out.Optimizer, uint8(1))
+	}
+}
+
+func TestSchemaErrors(t *testing.T) {
+	s := newSchema()
+	g := s.New()()
+
+	var out params
+	assert.Error(t, s.Decode(g, out))
+	assert.Error(t, s.Decode(&binary.Genome{}, &out))
+	assert.Error(t, s.Encode(g, 42))
+	assert.Error(t, s.Encode(g, params{Activation: "unknown"}))
+	assert.Error(t, s.Decode(g, &struct{ Dropout string }{}))
+
+	assert.Panics(t, func() { binary.Int("x", 10, 1) })
+	assert.Panics(t, func() { binary.Real("x", 0, 1, 0) })
+	assert.Panics(t, func() { binary.Enum("x") })
+}
+
+func TestSchemaUniform(t *testing.T) {
+	s := binary.NewSchema(binary.Int("x", 3, 7))
+	codes := make(map[int][]uint64)
+	for raw := uint64(0); raw < 8; raw++ {
+		g := binary.Genome{0}
+		for i := 0; i < 3; i++ {
+			g.SetBit(i, raw&(1<<i) != 0)
+		}
+
+		var out struct {
+			X int `binary:"x"`
+		}
+		assert.NoError(t, s.Decode(&g, &out))
+		codes[out.X] = append(codes[out.X], raw)
+	}
+
+	// Every value is hit by its own code, and the codes beyond the range wrap around
+	assert.Equal(t, map[int][]uint64{
+		3: {0, 5},
+		4: {1, 6},
+		5: {2, 7},
+		6: {3},
+		7: {4},
+	}, codes)
+}
+
+func TestGrayCode(t *testing.T) {
+	for i := uint64(0); i < 1024; i++ {
+		assert.Equal(t, i, binary.GrayDecode(binary.GrayEncode(i)))
+		diff := binary.GrayEncode(i) ^ binary.GrayEncode(i+1)
+		assert.Equal(t, 0, int(diff&(diff-1)), "adjacent codes must differ by one bit")
+	}
+}
+
+func TestSchemaEvolve(t *testing.T) {
+	type point struct{ X, Y float64 }
+	s := binary.NewSchema(
+		binary.Real("x", -10, 10, 20).Gray(),
+		binary.Real("y", -10, 10, 20).Gray(),
+	)
+
+	pop := evolve.New(256, func(g *binary.Chromosome) float32 {
+		var p point
+		s.Decode(&g.Genome, &p)
+		return float32(1 / (1 + math.Hypot(p.X-3, p.Y+2)))
+	}, binary.NewChromosome(s.Len(), binary.Operators{
+		Crossover: binary.Uniform(0.5),
+		Mutation:  binary.BitFlip(0.02),
+	}))
+
+	var last *binary.Chromosome
+	for i := 0; i < 500; i++ {
+		last = pop.Evolve()
+	}
+
+	var p point
+	assert.NoError(t, s.Decode(&last.Genome, &p))
+	assert.InDelta(t, 3, p.X, 0.1)
+	assert.InDelta(t, -2, p.Y, 0.1)
+}
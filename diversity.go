@@ -0,0 +1,96 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package evolve
+
+import (
+	"math"
+)
+
+// Distance represents a genome which can measure its distance to another genome
+type Distance[T any] interface {
+	Distance(other T) float64
+}
+
+// Diversity represents the diversity measures of an evaluated generation
+type Diversity struct {
+	Distance  float64 // The mean pairwise distance between genomes, or NaN if Distance is not implemented
+	Entropy   float64 // The entropy of the fitness distribution, in bits
+	Unique    int     // The number of unique fitness values
+	Variation float64 // The coefficient of variation of the fitness
+}
+
+// Diversity computes the diversity of the last evaluated generation. The genotypic diversity
+// is the mean distance between random pairs of genomes, where the number of sampled pairs
+// is limited to keep it cheap enough to compute every generation. If the number of samples
+// is not positive, all of the pairs are compared. The phenotypic diversity is computed
+// from the fitness of the whole generation.
+func (p *Population[T]) Diversity(samples int) Diversity {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	genomes := p.evaluated()
+	return Diversity{
+		Distance:  p.distance(genomes, samples),
+		Entropy:   p.entropy(len(genomes)),
+		Unique:    p.unique(len(genomes)),
+		Variation: p.variation(len(genomes)),
+	}
+}
+
+// distance computes the mean distance between the sampled pairs of genomes
+func (p *Population[T]) distance(genomes []T, samples int) float64 {
+	if len(genomes) < 2 {
+		return 0
+	}
+
+	if _, ok := any(genomes[0]).(Distance[T]); !ok {
+		return math.NaN()
+	}
+
+	// Compare every pair if there are fewer pairs than samples
+	sum, count := 0.0, 0
+	if pairs := len(genomes) * (len(genomes) - 1) / 2; samples <= 0 || samples >= pairs {
+		for i := range genomes {
+			for j := i + 1; j < len(genomes); j++ {
+				sum += any(genomes[i]).(Distance[T]).Distance(genomes[j])
+				count++
+			}
+		}
+		return sum / float64(count)
+	}
+
+	for ; count < samples; count++ {
+		i := p.rand.Intn(len(genomes))
+		j := p.rand.Intn(len(genomes) - 1)
+		if j >= i {
+			j++
+		}
+
+		sum += any(genomes[i]).(Distance[T]).Distance(genomes[j])
+	}
+	return sum / float64(count)
+}
+
+// entropy computes the entropy of the distribution of the fitness values, in bits
+func (p *Population[T]) entropy(n int) (entropy float64) {
+	counts := make(map[float32]int, n)
+	for i := 0; i < n; i++ {
+		counts[p.fitness(i)]++
+	}
+
+	for _, count := range counts {
+		pi := float64(count) / float64(n)
+		entropy -= pi * math.Log2(pi)
+	}
+	return
+}
+
+// unique counts the number of distinct fitness values
+func (p *Population[T]) unique(n int) int {
+	seen := make(map[float32]struct{}, n)
+	for i := 0; i < n; i++ {
+		seen[p.fitness(i)] = struct{}{}
+	}
+	return len(seen)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package evolve_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kelindar/evolve"
+	"github.com/kelindar/evolve/binary"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiversity(t *testing.T) {
+	pop := newPop(4, "hello")
+
+	genomes := make([]*binary.Genome, 0, 4)
+	for _, v := range []string{"hello", "hello", "hallo", "jelly"} {
+		g := binary.Genome(v)
+		genomes = append(genomes, &g)
+	}
+	pop.Seed(genomes...)
+
+	// Nothing was evaluated yet
+	before := pop.Diversity(0)
+	assert.Equal(t, 0.0, before.Entropy)
+	assert.Equal(t, 1, before.Unique)
+
+	// Compute the expected mean pairwise distance
+	var expect float64
+	for i := range genomes {
+		for j := i + 1; j < len(genomes); j++ {
+			expect += genomes[i].Distance(genomes[j])
+		}
+	}
+
+	pop.Evolve()
+	after := pop.Diversity(0)
+	assert.InDelta(t, expect/6, after.Distance, 1e-9)
+	assert.InDelta(t, 1.5, after.Entropy, 1e-9)
+	assert.Equal(t, 3, after.Unique)
+	assert.Greater(t, after.Variation, 0.0)
+
+	// Sampled distance stays within the range of the pairwise distances
+	sampled := pop.Diversity(3)
+	assert.GreaterOrEqual(t, sampled.Distance, 0.0)
+	assert.LessOrEqual(t, sampled.Distance, genomes[0].Distance(genomes[3]))
+}
+
+func TestDiversityCollapse(t *testing.T) {
+	pop := newPop(100, "hello")
+
+	genomes := make([]*binary.Genome, 100)
+	for i := range genomes {
+		g := binary.Genome("hallo")
+		genomes[i] = &g
+	}
+	pop.Seed(genomes...)
+	pop.Evolve()
+
+	diversity := pop.Diversity(50)
+	assert.Equal(t, 0.0, diversity.Distance)
+	assert.Equal(t, 0.0, diversity.Entropy)
+	assert.Equal(t, 1, diversity.Unique)
+	assert.Equal(t, 0.0, diversity.Variation)
+}
+
+func TestDiversityNoDistance(t *testing.T) {
+	pop := evolve.NewLegacy(10, func(*legacy) float32 {
+		return 1
+	}, func() *legacy {
+		return &legacy{Genome: *binary.New(5)()}
+	})
+
+	pop.Evolve()
+	assert.True(t, math.IsNaN(pop.Diversity(10).Distance))
+}
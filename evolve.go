@@ -4,37 +4,79 @@
 package evolve
 
 import (
+	"fmt"
+	"math"
 	"math/rand"
 	"runtime"
+	"sort"
 	"sync"
+
+	"github.com/kelindar/evolve/internal/sequence"
 )
 
-// Genome represents a genome contract.
-type Genome interface {
-	Crossover(Genome, Genome)
+// Genome represents a genome contract, where T is the concrete type of the genome itself so
+// that the crossover receives both parents without any type assertions.
+type Genome[T any] interface {
+	Crossover(T, T)
 	Mutate()
 	Reset()
 }
 
+// Legacy represents the untyped genome contract, where the crossover accepts any genome and
+// the implementation is responsible for asserting the type of the parents. It is provided
+// for compatibility and is used with NewLegacy.
+type Legacy interface {
+	Crossover(Legacy, Legacy)
+	Mutate()
+	Reset()
+}
+
+// Cloner represents a genome which can be copied into a new, independent genome
+type Cloner[T any] interface {
+	Clone() T
+}
+
+// Learning represents how the improvements found by a local search are passed on to the
+// offspring, in a hybrid (or memetic) evolution
+type Learning uint8
+
+// Supported learning modes
+const (
+	Lamarckian Learning = iota // The improved genomes replace the evaluated ones and are inherited
+	Baldwinian                 // Only the fitness of the improved genomes is kept, not the genomes
+)
+
 // Population represents a population for evolution
-type Population[T Genome] struct {
-	mu        sync.RWMutex
-	rand      *rand.Rand      // The random number generator
-	fitnessOf []float32       // The fitness cache
-	fitnessFn func(T) float32 // The fitness function
-	genomes   []T             // The current pool
-	pool      int             // The current pool index
-	pools     [2][]T          // The genome pools to avoid allocs
-}
-
-// New creates a new population controller. This function takes a population of fixed
-// size, a fitness function and a genome size (also of fixed size).
-func New[T Genome](n int, fitness func(T) float32, genesis func() T) *Population[T] {
+type Population[T Genome[T]] struct {
+	mu         sync.RWMutex
+	rand       *rand.Rand           // The random number generator
+	fitnessOf  []float32            // The fitness cache
+	fitnessFn  func(T) float32      // The fitness function
+	genesis    func() T             // The constructor of new genomes
+	schedule   func(int) int        // The population size schedule
+	sharing    func([]T, []float32) // The fitness sharing function
+	shared     []float32            // The shared fitness, used for the selection
+	search     func(T)              // The local search, applied before the evaluation
+	learning   Learning             // How the improvements of the local search are kept
+	policy     *Restart             // The restart policy
+	stagnation stagnation           // The stagnation tracker of the restart policy
+	lineage    *tracker             // The lineage tracker, if enabled
+	genomes    []T                  // The current pool
+	parents    []T                  // The last evaluated pool, which the fitness cache refers to
+	pool       int                  // The current pool index
+	pools      [2][]T               // The genome pools to avoid allocs
+	generation int                  // The current generation
+}
+
+// New creates a new population controller. This function takes the initial size of the
+// population, a fitness function and a constructor of random genomes.
+func New[T Genome[T]](n int, fitness func(T) float32, genesis func() T) *Population[T] {
 	p := &Population[T]{
 		rand:      rand.New(rand.NewSource(1)),
 		pools:     [2][]T{},
 		fitnessOf: make([]float32, n),
 		fitnessFn: fitness,
+		genesis:   genesis,
 	}
 
 	// Create double-buffer for the genome strings
@@ -50,16 +92,151 @@ func New[T Genome](n int, fitness func(T) float32, genesis func() T) *Population
 	return p
 }
 
+// NewLegacy creates a new population controller for genomes which implement the untyped
+// Legacy contract. This keeps the fitness function typed, while the genomes returned by the
+// population need to be asserted back to their concrete type.
+func NewLegacy[T Legacy](n int, fitness func(T) float32, genesis func() T) *Population[Legacy] {
+	return New(n, func(g Legacy) float32 {
+		return fitness(g.(T))
+	}, func() Legacy {
+		return genesis()
+	})
+}
+
 // Range iterates over the current set of genomes and their fitness
 func (p *Population[T]) Range(fn func(genome T, fitness float32)) {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 	for i, genome := range p.genomes {
-		fn(genome, p.fitnessOf[i])
+		fn(genome, p.fitness(i))
+	}
+}
+
+// Size returns the number of genomes in the current generation
+func (p *Population[T]) Size() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.genomes)
+}
+
+// Resize grows or shrinks the current generation to the specified size. When growing, the
+// population is filled with new random genomes, which is useful to widen the search (e.g.
+// on a restart), and when shrinking, the excess genomes are discarded before evaluation.
+func (p *Population[T]) Resize(n int) {
+	if n < 1 {
+		panic(fmt.Errorf("evolve: population size must be positive, got %d", n))
 	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.genomes = p.resize(p.genomes, n)
+	p.pools[p.pool] = p.genomes
+	p.lineage.resize(n, p.generation)
 }
 
-// Evolve evolves the population
+// SetSchedule sets the population size schedule, which returns the size of the population
+// for a generation, starting from the initial generation zero. The schedule is applied when
+// the offspring are bred and is typically used to start wide and narrow down later on, in
+// order to save evaluation budget.
+func (p *Population[T]) SetSchedule(schedule func(generation int) int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.schedule = schedule
+}
+
+// SetSharing sets a function which adjusts the fitness of the evaluated genomes before the
+// parents are selected, typically to share the fitness between similar genomes (niching) so
+// that new structures are protected while they are being optimized. The adjusted fitness is
+// only used for the selection, while the population keeps reporting the raw fitness.
+func (p *Population[T]) SetSharing(sharing func(genomes []T, fitness []float32)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sharing = sharing
+}
+
+// SetLocalSearch sets a local search which improves every genome before it is evaluated, such
+// as a few steps of gradient descent on a neural network. With the Lamarckian learning, the
+// improvements are written back into the genome and inherited by its offspring. With the
+// Baldwinian learning, the local search is applied to a copy and only its fitness is kept, so
+// that the selection favours the genomes which learn well; this requires the genomes to
+// implement Cloner, where the Clone of a legacy genome returns an evolve.Legacy. The local
+// search is called concurrently for different genomes.
+func (p *Population[T]) SetLocalSearch(learning Learning, search func(genome T)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Check an actual genome, since T is an interface for the legacy genomes
+	if learning == Baldwinian && len(p.genomes) > 0 {
+		if _, ok := any(p.genomes[0]).(Cloner[T]); !ok {
+			panic(fmt.Errorf("evolve: genome %T does not implement Cloner", p.genomes[0]))
+		}
+	}
+
+	p.search = search
+	p.learning = learning
+}
+
+// Linear returns a population size schedule which linearly changes the size from the initial
+// size to the final size over the specified number of generations, as in L-SHADE.
+func Linear(from, to, generations int) func(generation int) int {
+	return func(generation int) int {
+		if generation >= generations {
+			return to
+		}
+
+		return from + int(math.Round(float64(to-from)*float64(generation)/float64(generations)))
+	}
+}
+
+// Seed replaces the first individuals of the population with the specified genomes, which is
+// typically used to warm-start the evolution from known-good genomes. The population takes
+// the ownership of the genomes, so they must be distinct and must not be modified afterwards.
+func (p *Population[T]) Seed(genomes ...T) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := 0; i < len(genomes) && i < len(p.genomes); i++ {
+		p.genomes[i] = genomes[i]
+		p.lineage.replace(i, Seeded, p.generation)
+	}
+}
+
+// Inject replaces random individuals of the population with the specified immigrants, which
+// are going to compete in the next generation. The population takes the ownership of the
+// genomes, so they must be distinct and must not be modified afterwards.
+func (p *Population[T]) Inject(genomes ...T) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, j := range p.rand.Perm(len(p.genomes)) {
+		if i >= len(genomes) {
+			return
+		}
+		p.genomes[j] = genomes[i]
+		p.lineage.replace(j, Injected, p.generation)
+	}
+}
+
+// Top returns independent copies of the k fittest genomes of the last evaluated generation,
+// sorted by their fitness in descending order. The genomes must implement Cloner.
+func (p *Population[T]) Top(k int) []T {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	genomes := p.evaluated()
+	rank := p.rank(len(genomes))
+	if k > len(rank) {
+		k = len(rank)
+	}
+
+	out := make([]T, 0, k)
+	for _, i := range rank[:k] {
+		out = append(out, clone(genomes[i]))
+	}
+	return out
+}
+
+// Evolve evolves the population and returns the fittest genome of the evaluated generation.
+// The fittest genome remains owned by the population and is overwritten in the subsequent
+// generations, hence Top should be used to retain a copy of it.
 func (p *Population[T]) Evolve() (fittest T) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -67,6 +244,17 @@ func (p *Population[T]) Evolve() (fittest T) {
 	// Parallelize the fitness evaluation
 	p.evaluate(runtime.NumCPU())
 
+	// Restart the population if it stagnates, and evaluate it again
+	if p.restart() {
+		p.evaluate(runtime.NumCPU())
+	}
+
+	// Share the fitness between similar genomes for the selection
+	if p.sharing != nil {
+		p.shared = append(p.shared[:0], p.fitnessOf...)
+		p.sharing(p.genomes, p.shared)
+	}
+
 	// Find the fittest genome
 	best := float32(0)
 	for i := range p.genomes {
@@ -76,28 +264,39 @@ func (p *Population[T]) Evolve() (fittest T) {
 		}
 	}
 
+	// Determine the size of the next generation
+	size := len(p.genomes)
+	if p.schedule != nil {
+		size = p.schedule(p.generation + 1)
+	}
+
 	p.pool = (p.pool + 1) % 2
-	buffer := p.pools[p.pool]
-	for i := range p.genomes {
+	buffer := p.resize(p.pools[p.pool], size)
+	p.pools[p.pool] = buffer
+	for i := range buffer {
 
 		// Select 2 parents
 		p1, p2 := p.pickParents()
 
 		// Perform the crossover
 		gene := buffer[i]
-		gene.Crossover(p1, p2)
+		gene.Crossover(p.genomes[p1], p.genomes[p2])
 
 		// Mutate the genome
 		gene.Mutate()
+		p.lineage.breed(i, p1, p2, p.generation+1, gene)
 	}
 
 	// Write the genome pool
+	p.lineage.advance()
+	p.parents = p.genomes
 	p.genomes = buffer
+	p.generation++
 	return
 }
 
-// pickParents selects 2 parents from the population and sorts them by their fitness.
-func (p *Population[T]) pickParents() (T, T) {
+// pickParents selects the indices of 2 parents from the population and sorts them by their fitness.
+func (p *Population[T]) pickParents() (int, int) {
 	p1, f1 := p.pickMate()
 	p2, f2 := p.pickMate()
 	if f1 > f2 {
@@ -107,21 +306,93 @@ func (p *Population[T]) pickParents() (T, T) {
 	return p2, p1
 }
 
-// pickMate selects a parent from the population using a tournament selection.
-func (p *Population[T]) pickMate() (bestEvolver T, bestFitness float32) {
+// pickMate selects the index of a parent from the population using a tournament selection.
+func (p *Population[T]) pickMate() (bestEvolver int, bestFitness float32) {
 	const tournamentSize = 4
+	fitness := p.fitnessOf
+	if p.sharing != nil {
+		fitness = p.shared
+	}
+
 	for r := 0; r < tournamentSize; r++ {
-		i := p.rand.Int31n(int32(len(p.genomes)))
-		if f := p.fitnessOf[i]; f >= bestFitness || bestFitness == 0 {
-			bestEvolver = p.genomes[i]
+		i := int(p.rand.Int31n(int32(len(p.genomes))))
+		if f := fitness[i]; f >= bestFitness || bestFitness == 0 {
+			bestEvolver = i
 			bestFitness = f
 		}
 	}
 	return
 }
 
+// resize resizes the pool to the specified size, creating new genomes if necessary
+func (p *Population[T]) resize(pool []T, n int) []T {
+	if n < 1 {
+		n = 1
+	}
+
+	if n <= len(pool) {
+		return pool[:n]
+	}
+
+	for len(pool) < n {
+		pool = append(pool, p.genesis())
+	}
+	return pool
+}
+
+// evaluated returns the last evaluated pool, which the fitness cache refers to
+func (p *Population[T]) evaluated() []T {
+	if p.parents != nil {
+		return p.parents
+	}
+	return p.genomes
+}
+
+// rank returns the indices of the first n genomes, sorted by their fitness in descending order
+func (p *Population[T]) rank(n int) []int {
+	rank := make([]int, n)
+	for i := range rank {
+		rank[i] = i
+	}
+
+	sort.SliceStable(rank, func(i, j int) bool {
+		return p.fitness(rank[i]) > p.fitness(rank[j])
+	})
+	return rank
+}
+
+// fitness returns the cached fitness of the genome at the specified index
+func (p *Population[T]) fitness(i int) float32 {
+	if i < len(p.fitnessOf) {
+		return p.fitnessOf[i]
+	}
+	return 0
+}
+
+// clone returns a copy of the genome, which must implement Cloner
+func clone[T any](genome T) T {
+	c, ok := any(genome).(Cloner[T])
+	if !ok {
+		panic(fmt.Errorf("evolve: genome %T does not implement Cloner", genome))
+	}
+	return c.Clone()
+}
+
+// refine applies the local search to the genome, or to a copy of it with the Baldwinian
+// learning, and returns the genome to evaluate
+func (p *Population[T]) refine(genome T) T {
+	if p.learning == Baldwinian {
+		genome = clone(genome)
+	}
+
+	p.search(genome)
+	genome.Reset()
+	return genome
+}
+
 // evaluate evaluates the population in parallel
 func (p *Population[T]) evaluate(parallelism int) {
+	p.fitnessOf = sequence.Resize(p.fitnessOf, len(p.genomes))
 	chunkSize := len(p.genomes) / parallelism
 	var wg sync.WaitGroup
 	wg.Add(parallelism)
@@ -138,6 +409,9 @@ func (p *Population[T]) evaluate(parallelism int) {
 			for j := start; j < end; j++ {
 				v := p.genomes[j]
 				v.Reset()
+				if p.search != nil {
+					v = p.refine(v)
+				}
 
 				// Evaluate the fitness
 				fitness := p.fitnessFn(v)
@@ -148,4 +422,5 @@ func (p *Population[T]) evaluate(parallelism int) {
 	}
 
 	wg.Wait()
+	p.lineage.evaluated(p.fitnessOf)
 }
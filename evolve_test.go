@@ -75,6 +75,241 @@ func TestRange(t *testing.T) {
 	assert.Equal(t, 100, count)
 }
 
+func TestSeed(t *testing.T) {
+	const target = "hello"
+	pop := newPop(100, target)
+
+	seed := binary.Genome(target)
+	pop.Seed(&seed)
+	assert.Equal(t, target, pop.Evolve().String())
+}
+
+func TestInject(t *testing.T) {
+	const target = "hello"
+	pop := newPop(100, target)
+	pop.Evolve()
+
+	immigrants := make([]*binary.Genome, 10)
+	for i := range immigrants {
+		g := binary.Genome(target)
+		immigrants[i] = &g
+	}
+
+	pop.Inject(immigrants...)
+	count := 0
+	pop.Range(func(genome *binary.Genome, _ float32) {
+		if genome.String() == target {
+			count++
+		}
+	})
+
+	assert.GreaterOrEqual(t, count, 10)
+	assert.Equal(t, target, pop.Evolve().String())
+}
+
+func TestTop(t *testing.T) {
+	const target = "hello"
+	fit := fitnessFor(target)
+	pop := newPop(100, target)
+
+	seed := binary.Genome(target)
+	pop.Seed(&seed)
+	pop.Evolve()
+
+	top := pop.Top(5)
+	assert.Len(t, top, 5)
+	assert.Equal(t, target, top[0].String())
+	assert.NotSame(t, &seed, top[0])
+	for i := 1; i < len(top); i++ {
+		assert.GreaterOrEqual(t, fit(top[i-1]), fit(top[i]))
+	}
+
+	// The copies are not affected by the evolution
+	for i := 0; i < 10; i++ {
+		pop.Evolve()
+	}
+
+	assert.Equal(t, target, top[0].String())
+	assert.Len(t, pop.Top(1000), 100)
+}
+
+func TestTopNoClone(t *testing.T) {
+	pop := evolve.NewLegacy(10, func(g *legacy) float32 {
+		return 0
+	}, func() *legacy {
+		return &legacy{Genome: *binary.New(2)()}
+	})
+
+	assert.Panics(t, func() {
+		pop.Top(1)
+	})
+}
+
+func TestResize(t *testing.T) {
+	const target = "hello"
+	pop := newPop(100, target)
+	pop.Evolve()
+
+	pop.Resize(300)
+	assert.Equal(t, 300, pop.Size())
+	assert.Len(t, pop.Top(1000), 100)
+	pop.Evolve()
+	assert.Len(t, pop.Top(1000), 300)
+
+	pop.Resize(20)
+	assert.Equal(t, 20, pop.Size())
+	pop.Evolve()
+	assert.Len(t, pop.Top(1000), 20)
+
+	count := 0
+	pop.Range(func(*binary.Genome, float32) {
+		count++
+	})
+	assert.Equal(t, 20, count)
+	assert.Panics(t, func() {
+		pop.Resize(0)
+	})
+}
+
+func TestSchedule(t *testing.T) {
+	const target = "hello"
+	pop := newPop(256, target)
+	pop.SetSchedule(evolve.Linear(256, 32, 50))
+
+	sizes := []int{}
+	for i := 0; i < 100; i++ {
+		pop.Evolve()
+		sizes = append(sizes, pop.Size())
+	}
+
+	assert.Equal(t, 252, sizes[0])
+	assert.Equal(t, 32, sizes[49])
+	assert.Equal(t, 32, sizes[99])
+	assert.Len(t, pop.Top(1000), 32)
+}
+
+func TestLinear(t *testing.T) {
+	schedule := evolve.Linear(100, 10, 9)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, 100-i*10, schedule(i))
+	}
+
+	assert.Equal(t, 10, schedule(100))
+	assert.Equal(t, 20, evolve.Linear(10, 20, 10)(10))
+}
+
+func TestSharing(t *testing.T) {
+	const target = "hello"
+	pop := newPop(64, target)
+	seed := binary.Genome(target)
+	pop.Seed(&seed)
+
+	calls := 0
+	pop.SetSharing(func(genomes []*binary.Genome, fitness []float32) {
+		assert.Len(t, fitness, len(genomes))
+		for i := range fitness {
+			fitness[i] = 0
+		}
+		calls++
+	})
+
+	// The shared fitness is only used for the selection
+	fittest := pop.Evolve()
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, target, fittest.String())
+	assert.Greater(t, pop.Diversity(0).Unique, 1)
+}
+
+func TestLocalSearch(t *testing.T) {
+	const target = "hello"
+	for _, learning := range []evolve.Learning{evolve.Lamarckian, evolve.Baldwinian} {
+		pop := newPop(64, target)
+		pop.SetLocalSearch(learning, func(genome *binary.Genome) {
+			copy(*genome, target)
+		})
+
+		// Every genome is evaluated after the local search
+		fittest := pop.Evolve()
+		pop.Range(func(_ *binary.Genome, fitness float32) {
+			assert.Equal(t, float32(1), fitness)
+		})
+
+		// Only the Lamarckian learning keeps the improved genomes
+		switch learning {
+		case evolve.Lamarckian:
+			assert.Equal(t, target, fittest.String())
+		case evolve.Baldwinian:
+			assert.NotEqual(t, target, fittest.String())
+		}
+	}
+
+	assert.Panics(t, func() {
+		evolve.NewLegacy(10, func(g *legacy) float32 {
+			return 0
+		}, func() *legacy {
+			return &legacy{Genome: *binary.New(2)()}
+		}).SetLocalSearch(evolve.Baldwinian, func(evolve.Legacy) {})
+	})
+
+	// The legacy genomes which can be copied support the Baldwinian learning as well
+	pop := evolve.NewLegacy(64, func(g *clonable) float32 {
+		return fitnessFor(target)(&g.Genome)
+	}, func() *clonable {
+		return &clonable{Genome: *binary.New(len(target))()}
+	})
+	pop.SetLocalSearch(evolve.Baldwinian, func(g evolve.Legacy) {
+		copy(g.(*clonable).Genome, target)
+	})
+
+	fittest := pop.Evolve().(*clonable)
+	pop.Range(func(_ evolve.Legacy, fitness float32) {
+		assert.Equal(t, float32(1), fitness)
+	})
+	assert.NotEqual(t, target, fittest.String())
+}
+
+func TestLegacy(t *testing.T) {
+	const target = "hello"
+	fit := fitnessFor(target)
+	pop := evolve.NewLegacy(256, func(g *legacy) float32 {
+		return fit(&g.Genome)
+	}, func() *legacy {
+		return &legacy{Genome: *binary.New(len(target))()}
+	})
+
+	// Evolve
+	var last *legacy
+	for i := 0; i < 100000; i++ {
+		if last = pop.Evolve().(*legacy); last.String() == target {
+			break
+		}
+	}
+
+	assert.Equal(t, target, last.String())
+}
+
+// legacy represents a genome which implements the untyped contract
+type legacy struct {
+	binary.Genome
+}
+
+func (g *legacy) Crossover(p1, p2 evolve.Legacy) {
+	g.Genome.Crossover(&p1.(*legacy).Genome, &p2.(*legacy).Genome)
+}
+
+// clonable represents a genome which implements the untyped contract and can be copied
+type clonable struct {
+	binary.Genome
+}
+
+func (g *clonable) Crossover(p1, p2 evolve.Legacy) {
+	g.Genome.Crossover(&p1.(*clonable).Genome, &p2.(*clonable).Genome)
+}
+
+func (g *clonable) Clone() evolve.Legacy {
+	return &clonable{Genome: *g.Genome.Clone()}
+}
+
 // newPop returns a new population for tests
 func newPop(n int, target string) *evolve.Population[*binary.Genome] {
 	fit := fitnessFor(target)
@@ -0,0 +1,151 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package grammar
+
+import (
+	"fmt"
+	mrand "math/rand"
+)
+
+// Config represents the configuration of a grammatical evolution genome
+type Config struct {
+	Grammar      *Grammar // The grammar used to map the codons into programs
+	Length       int      // The number of codons
+	Values       int      // The number of distinct codon values (default: 256)
+	Wraps        int      // The maximum number of times the codons are re-used (default: 2)
+	MutationRate float64  // The probability of mutating each codon (default: 1 / length)
+}
+
+// Genome represents a string of integer codons which is mapped into a program
+type Genome struct {
+	codons  []int   // The codons of the genome
+	conf    *Config // The shared configuration
+	program string  // The cached program
+	err     error   // The cached mapping error
+	mapped  bool    // Whether the program was mapped
+}
+
+// New creates a function for a random codon string which is mapped into programs using the
+// grammar specified in the configuration.
+func New(config Config) func() *Genome {
+	if config.Grammar == nil || config.Length <= 0 {
+		panic(fmt.Errorf("grammar: a grammar and a positive length are required"))
+	}
+
+	conf := config
+	if conf.Values <= 0 {
+		conf.Values = 256
+	}
+	if conf.Wraps <= 0 {
+		conf.Wraps = 2
+	}
+	if conf.MutationRate == 0 {
+		conf.MutationRate = 1 / float64(conf.Length)
+	}
+
+	return func() *Genome {
+		g := &Genome{
+			codons: make([]int, conf.Length),
+			conf:   &conf,
+		}
+
+		for i := range g.codons {
+			g.codons[i] = mrand.Intn(conf.Values)
+		}
+		return g
+	}
+}
+
+// Program maps the genome into a program, returning ErrInvalid if the mapping did not
+// complete within the wrapping limit. The result is cached until the genome changes.
+func (g *Genome) Program() (string, error) {
+	if !g.mapped {
+		g.program, g.err = g.conf.Grammar.Map(g.codons, g.conf.Wraps)
+		g.mapped = true
+	}
+
+	return g.program, g.err
+}
+
+// Codons returns a copy of the codons of the genome
+func (g *Genome) Codons() []int {
+	return append([]int(nil), g.codons...)
+}
+
+// SetCodons replaces the codons of the genome, which must have the configured length, and
+// clears the cached program.
+func (g *Genome) SetCodons(codons []int) {
+	if len(codons) != len(g.codons) {
+		panic(fmt.Errorf("grammar: expected %d codons, got %d", len(g.codons), len(codons)))
+	}
+
+	copy(g.codons, codons)
+	g.mapped = false
+}
+
+// Valid returns whether the genome maps into a valid program
+func (g *Genome) Valid() bool {
+	_, err := g.Program()
+	return err == nil
+}
+
+// Crossover implements a one-point crossover of the codons
+func (g *Genome) Crossover(p1, p2 *Genome) {
+	v1, v2 := p1.codons, p2.codons
+	cut := mrand.Intn(len(v1) + 1)
+	copy(g.codons, v1[:cut])
+	copy(g.codons[cut:], v2[cut:])
+	g.mapped = false
+}
+
+// Mutate replaces every codon with a random value, with the configured rate
+func (g *Genome) Mutate() {
+	for i := range g.codons {
+		if mrand.Float64() < g.conf.MutationRate {
+			g.codons[i] = mrand.Intn(g.conf.Values)
+			g.mapped = false
+		}
+	}
+}
+
+// Reset resets the internal state, no-op in this case
+func (g *Genome) Reset() {
+	// No state
+}
+
+// Clone returns a copy of the genome, sharing the same configuration
+func (g *Genome) Clone() *Genome {
+	return &Genome{
+		codons:  append([]int(nil), g.codons...),
+		conf:    g.conf,
+		program: g.program,
+		err:     g.err,
+		mapped:  g.mapped,
+	}
+}
+
+// String returns the program, or a placeholder if the genome is invalid
+func (g *Genome) String() string {
+	if g == nil {
+		return "<nil>"
+	}
+
+	program, err := g.Program()
+	if err != nil {
+		return "<invalid>"
+	}
+	return program
+}
+
+// Fitness wraps a fitness function of a program, so that invalid individuals are
+// assigned the worst fitness and the program does not need to be mapped by the caller.
+func Fitness(fitness func(program string) float32) func(*Genome) float32 {
+	return func(g *Genome) float32 {
+		program, err := g.Program()
+		if err != nil {
+			return 0
+		}
+		return fitness(program)
+	}
+}
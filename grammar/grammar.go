@@ -0,0 +1,218 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package grammar
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// maxExpansions is the maximum number of expansions of a single derivation, which guards
+// against grammars with cycles which do not consume any codons.
+const maxExpansions = 1 << 20
+
+var (
+	// ErrInvalid is returned when an individual cannot be mapped into a program
+	ErrInvalid = errors.New("grammar: derivation exceeded the wrapping limit")
+)
+
+// symbol represents a terminal text or a non-terminal reference in a production
+type symbol struct {
+	text     string // The literal text of a terminal
+	rule     int    // The index of the non-terminal rule
+	terminal bool   // Whether this is a terminal
+}
+
+// production represents a single choice of a rule
+type production []symbol
+
+// rule represents a non-terminal along with its choices
+type rule struct {
+	name    string
+	choices []production
+}
+
+// Grammar represents a context-free grammar in Backus-Naur form
+type Grammar struct {
+	rules []rule
+	index map[string]int
+}
+
+// Parse parses a grammar in Backus-Naur form, where every rule is defined as
+// "<name> ::= choice | choice", with the first rule being the start symbol. Choices
+// can span multiple lines, and the literal text is copied as-is into the program
+// unless quoted, in which case the quotes are removed. Quotes are required for any literal
+// text which contains '<' or '|' characters.
+func Parse(bnf string) (*Grammar, error) {
+	g := &Grammar{index: make(map[string]int)}
+	bodies := make([]string, 0, 8)
+
+	// Split the definitions into rules, supporting continuation lines
+	for n, line := range strings.Split(bnf, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case strings.Contains(line, "::="):
+			head, body, _ := strings.Cut(line, "::=")
+			name := strings.TrimSpace(head)
+			if !isNonTerminal(name) {
+				return nil, fmt.Errorf("grammar: invalid rule name %q on line %d", name, n+1)
+			}
+
+			if _, exists := g.index[name]; exists {
+				return nil, fmt.Errorf("grammar: duplicate rule %s on line %d", name, n+1)
+			}
+
+			g.index[name] = len(g.rules)
+			g.rules = append(g.rules, rule{name: name})
+			bodies = append(bodies, body)
+		case len(bodies) > 0:
+			bodies[len(bodies)-1] += " " + line
+		default:
+			return nil, fmt.Errorf("grammar: unexpected %q on line %d", line, n+1)
+		}
+	}
+
+	if len(g.rules) == 0 {
+		return nil, fmt.Errorf("grammar: no rules defined")
+	}
+
+	// Parse the choices of every rule
+	for i, body := range bodies {
+		for _, choice := range splitChoices(body) {
+			p, err := g.parseProduction(strings.TrimSpace(choice))
+			if err != nil {
+				return nil, err
+			}
+
+			g.rules[i].choices = append(g.rules[i].choices, p)
+		}
+	}
+	return g, nil
+}
+
+// MustParse parses a grammar in Backus-Naur form and panics if it is invalid
+func MustParse(bnf string) *Grammar {
+	g, err := Parse(bnf)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// Map maps the codons into a program by expanding the leftmost non-terminal, choosing the
+// production with the next codon modulo the number of choices. When the codons run out, they
+// are re-used from the beginning up to the specified number of wraps, after which the
+// individual is considered invalid and an error is returned.
+func (g *Grammar) Map(codons []int, wraps int) (string, error) {
+	return derive(g, codons, wraps)
+}
+
+// MapBytes maps the bytes, such as a binary genome, into a program. See Map for details.
+func (g *Grammar) MapBytes(codons []byte, wraps int) (string, error) {
+	return derive(g, codons, wraps)
+}
+
+// derive performs the genotype to phenotype mapping
+func derive[T int | byte](g *Grammar, codons []T, wraps int) (string, error) {
+	var out strings.Builder
+	stack := []symbol{{rule: 0}}
+	used, limit := 0, len(codons)*(wraps+1)
+	for expansions := 0; len(stack) > 0; expansions++ {
+		if expansions > maxExpansions {
+			return "", ErrInvalid
+		}
+
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if s.terminal {
+			out.WriteString(s.text)
+			continue
+		}
+
+		// Choose the production, without consuming a codon if there is no choice
+		choices := g.rules[s.rule].choices
+		choice := 0
+		if len(choices) > 1 {
+			if used >= limit {
+				return "", ErrInvalid
+			}
+
+			choice = int(codons[used%len(codons)]) % len(choices)
+			used++
+		}
+
+		// Push the symbols in the reverse order, so the leftmost is expanded first
+		p := choices[choice]
+		for i := len(p) - 1; i >= 0; i-- {
+			stack = append(stack, p[i])
+		}
+	}
+
+	return out.String(), nil
+}
+
+// parseProduction parses a single choice into a list of symbols
+func (g *Grammar) parseProduction(text string) (production, error) {
+	var p production
+	for len(text) > 0 {
+		switch text[0] {
+		case '<':
+			end := strings.IndexByte(text, '>')
+			if end < 0 {
+				return nil, fmt.Errorf("grammar: unterminated non-terminal in %q", text)
+			}
+
+			idx, ok := g.index[text[:end+1]]
+			if !ok {
+				return nil, fmt.Errorf("grammar: undefined rule %s", text[:end+1])
+			}
+
+			p = append(p, symbol{rule: idx})
+			text = text[end+1:]
+		case '"':
+			end := strings.IndexByte(text[1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("grammar: unterminated quote in %q", text)
+			}
+
+			p = append(p, symbol{text: text[1 : end+1], terminal: true})
+			text = text[end+2:]
+		default:
+			end := strings.IndexAny(text, "<\"")
+			if end < 0 {
+				end = len(text)
+			}
+
+			p = append(p, symbol{text: text[:end], terminal: true})
+			text = text[end:]
+		}
+	}
+	return p, nil
+}
+
+// splitChoices splits the body of a rule on '|' characters which are not quoted
+func splitChoices(body string) (choices []string) {
+	quoted, start := false, 0
+	for i := 0; i < len(body); i++ {
+		switch body[i] {
+		case '"':
+			quoted = !quoted
+		case '|':
+			if !quoted {
+				choices = append(choices, body[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(choices, body[start:])
+}
+
+// isNonTerminal checks whether the text is a valid non-terminal name
+func isNonTerminal(text string) bool {
+	return len(text) > 2 && text[0] == '<' && text[len(text)-1] == '>' &&
+		!strings.ContainsAny(text[1:len(text)-1], "<> \t")
+}
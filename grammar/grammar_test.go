@@ -0,0 +1,217 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package grammar_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kelindar/evolve"
+	"github.com/kelindar/evolve/binary"
+	"github.com/kelindar/evolve/grammar"
+	"github.com/stretchr/testify/assert"
+)
+
+const arithmetic = `
+# Fully parenthesized arithmetic expressions
+<expr> ::= (<expr><op><expr>)
+         | <var>
+<op>   ::= + | - | *
+<var>  ::= x | 1
+`
+
+func TestParse(t *testing.T) {
+	_, err := grammar.Parse(arithmetic)
+	assert.NoError(t, err)
+
+	for _, bnf := range []string{
+		"",
+		"<a> ::= <b>",
+		"a ::= x",
+		"<a> ::= x\n<a> ::= y",
+		"<a> ::= <b",
+		`<a> ::= "x`,
+		"| x",
+	} {
+		_, err := grammar.Parse(bnf)
+		assert.Error(t, err, bnf)
+	}
+
+	assert.Panics(t, func() {
+		grammar.MustParse("")
+	})
+}
+
+func TestMap(t *testing.T) {
+	g := grammar.MustParse(arithmetic)
+
+	// <expr> -> (<expr><op><expr>) -> (<var><op><expr>) -> (x<op><expr>) -> (x*<expr>) -> (x*<var>) -> (x*1)
+	program, err := g.Map([]int{0, 1, 0, 2, 1, 1}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "(x*1)", program)
+
+	program, err = g.MapBytes([]byte{2, 1, 2, 5, 3, 3}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "(x*1)", program)
+}
+
+func TestMapWrapping(t *testing.T) {
+	g := grammar.MustParse(arithmetic)
+
+	// Requires 6 codons, so wrapping 3 codons once is enough
+	program, err := g.Map([]int{0, 1, 0}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "(x+x)", program)
+
+	_, err = g.Map([]int{0, 1, 0}, 0)
+	assert.ErrorIs(t, err, grammar.ErrInvalid)
+
+	// Always choosing the recursive production never terminates
+	_, err = g.Map([]int{0}, 100)
+	assert.ErrorIs(t, err, grammar.ErrInvalid)
+}
+
+func TestMapQuoted(t *testing.T) {
+	g := grammar.MustParse(`
+<rule> ::= if <cond> then <action>
+<cond> ::= "a < b" | "a | b"
+<action> ::= allow | deny`)
+
+	program, err := g.Map([]int{1, 0}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "if a | b then allow", program)
+}
+
+func TestMapCycle(t *testing.T) {
+	g := grammar.MustParse("<a> ::= <b>\n<b> ::= <a>")
+	_, err := g.Map([]int{0}, 0)
+	assert.ErrorIs(t, err, grammar.ErrInvalid)
+}
+
+func TestEvolve(t *testing.T) {
+	pop := evolve.New(256, grammar.Fitness(evaluate), grammar.New(grammar.Config{
+		Grammar: grammar.MustParse(arithmetic),
+		Length:  50,
+	}))
+
+	var last *grammar.Genome
+	for i := 0; i < 500; i++ {
+		if last = pop.Evolve(); grammar.Fitness(evaluate)(last) == 1 {
+			break
+		}
+	}
+
+	last.Reset()
+	assert.True(t, last.Valid())
+	assert.Equal(t, float32(1), grammar.Fitness(evaluate)(last), last.String())
+}
+
+func TestEvolveBinary(t *testing.T) {
+	g := grammar.MustParse(arithmetic)
+	pop := evolve.New(256, func(c *binary.Chromosome) float32 {
+		program, err := g.MapBytes(c.Genome, 2)
+		if err != nil {
+			return 0
+		}
+		return evaluate(program)
+	}, binary.NewChromosome(50, binary.Operators{
+		Crossover: binary.OnePoint(),
+		Mutation:  binary.BitFlip(0.002),
+	}))
+
+	var program string
+	var err error
+	for i := 0; i < 2000; i++ {
+		last := pop.Evolve()
+		if program, err = g.MapBytes(last.Genome, 2); err == nil && evaluate(program) == 1 {
+			break
+		}
+	}
+
+	assert.NoError(t, err)
+	assert.Equal(t, float32(1), evaluate(program), program)
+}
+
+func TestClone(t *testing.T) {
+	genome := grammar.New(grammar.Config{
+		Grammar: grammar.MustParse(arithmetic),
+		Length:  20,
+	})()
+
+	clone := genome.Clone()
+	assert.Equal(t, genome.String(), clone.String())
+	codons := clone.Codons()
+	codons[0]++
+	clone.SetCodons(codons)
+	assert.NotEqual(t, genome.Codons(), clone.Codons())
+}
+
+func TestInvalid(t *testing.T) {
+	genome := grammar.New(grammar.Config{
+		Grammar: grammar.MustParse(arithmetic),
+		Length:  1,
+	})()
+
+	genome.SetCodons([]int{0})
+	genome.Mutate()
+	genome.SetCodons([]int{0})
+	genome.Crossover(genome, genome)
+	assert.False(t, genome.Valid())
+	assert.Equal(t, "<invalid>", genome.String())
+	assert.Equal(t, float32(0), grammar.Fitness(evaluate)(genome))
+
+	assert.Panics(t, func() {
+		grammar.New(grammar.Config{})
+	})
+
+	assert.Panics(t, func() {
+		genome.SetCodons([]int{0, 0})
+	})
+}
+
+func TestSetCodons(t *testing.T) {
+	genome := grammar.New(grammar.Config{
+		Grammar: grammar.MustParse(arithmetic),
+		Length:  1,
+	})()
+
+	// The cached program is cleared when the codons are replaced
+	genome.SetCodons([]int{0})
+	assert.False(t, genome.Valid())
+	genome.SetCodons([]int{1})
+	assert.Equal(t, []int{1}, genome.Codons())
+	assert.Equal(t, "1", genome.String())
+}
+
+// evaluate evaluates the program against x^2 + x + 1
+func evaluate(program string) float32 {
+	var errors float64
+	for x := -2.0; x <= 2.0; x += 0.5 {
+		value, _ := eval(program, x)
+		errors += math.Abs(value - (x*x + x + 1))
+	}
+	return float32(1 / (1 + errors))
+}
+
+// eval evaluates a fully parenthesized expression
+func eval(program string, x float64) (float64, string) {
+	switch program[0] {
+	case 'x':
+		return x, program[1:]
+	case '1':
+		return 1, program[1:]
+	}
+
+	lhs, rest := eval(program[1:], x)
+	op := rest[0]
+	rhs, rest := eval(rest[1:], x)
+	switch op {
+	case '+':
+		return lhs + rhs, rest[1:]
+	case '-':
+		return lhs - rhs, rest[1:]
+	default:
+		return lhs * rhs, rest[1:]
+	}
+}
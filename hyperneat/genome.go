@@ -0,0 +1,198 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package hyperneat
+
+import (
+	"fmt"
+
+	"github.com/kelindar/evolve/neat"
+	"github.com/kelindar/evolve/neural"
+)
+
+// Config represents the configuration of a HyperNEAT genome
+type Config struct {
+	Substrate Substrate   // The layout of the generated network
+	Threshold float32     // The magnitude below which the connections are not expressed (default: 0.2)
+	Scale     float32     // The maximum magnitude of the weights (default: 3)
+	CPPN      neat.Config // The configuration of the CPPN, whose inputs and outputs are set by the encoding
+}
+
+// Genome represents an indirect encoding of a neural network, where a small evolved CPPN
+// (compositional pattern producing network) generates the weight of every connection of a
+// substrate from the coordinates of the neurons it connects. Since the genome does not grow
+// with the substrate, large networks with regular connectivity can be evolved cheaply. The
+// layers of the substrate are feed-forward layers, which have no bias, hence the CPPN is only
+// queried for the weights of the connections and not for the bias of the neurons.
+type Genome struct {
+	cppn     *neat.Genome    // The evolved CPPN
+	conf     *config         // The shared configuration
+	weights  [][]float32     // The generated weights of every layer
+	network  *neural.Network // The generated network
+	compiled bool            // Whether the network is up to date
+}
+
+// config represents the configuration shared by the genomes of a population
+type config struct {
+	Config
+}
+
+// New creates a function for random genomes with the specified configuration. The CPPN
+// receives the coordinates (x, y, depth) of both neurons of a connection, and its activation
+// defaults to tanh so that it generates both positive and negative weights. Unless specified,
+// its hidden nodes select among the sine, gaussian, absolute and sigmoid activations, which
+// produce the repetition and the symmetry of the generated patterns.
+func New(c Config) func() *Genome {
+	if err := c.Substrate.validate(); err != nil {
+		panic(err)
+	}
+
+	conf := &config{Config: c}
+	conf.Threshold = defaultOf(conf.Threshold, 0.2)
+	conf.Scale = defaultOf(conf.Scale, 3)
+	if conf.Threshold < 0 || conf.Threshold >= 1 {
+		panic(fmt.Errorf("hyperneat: threshold must be within [0, 1), got %v", conf.Threshold))
+	}
+
+	conf.CPPN.Inputs = 6
+	conf.CPPN.Outputs = 1
+	conf.CPPN.Recurrent = false
+	if conf.CPPN.Activation == nil {
+		conf.CPPN.Activation = neat.Tanh
+	}
+	if conf.CPPN.Activations == nil {
+		conf.CPPN.Activations = []func(float32) float32{neat.Sine, neat.Gaussian, neat.Abs, neat.Sigmoid}
+	}
+
+	cppn := neat.New(conf.CPPN)
+	return func() *Genome {
+		return &Genome{
+			cppn: cppn(),
+			conf: conf,
+		}
+	}
+}
+
+// CPPN returns the evolved CPPN of the genome
+func (g *Genome) CPPN() *neat.Genome {
+	return g.cppn
+}
+
+// Network returns the network generated by the CPPN. The network is built once, and its
+// weights are generated again in place when the genome changes.
+func (g *Genome) Network() *neural.Network {
+	if !g.compiled {
+		g.generate()
+		if g.network == nil {
+			g.network = neural.NewNetworkFrom(g.conf.Substrate.architecture(), g.weights...)
+		} else {
+			g.network.SetWeights(g.weights...)
+		}
+		g.compiled = true
+	}
+	return g.network
+}
+
+// Predict performs a forward propagation through the generated network
+func (g *Genome) Predict(input, output []float32) []float32 {
+	return g.Network().Predict(input, output)
+}
+
+// Crossover performs the crossover of the CPPNs of both parents, where the first parent is
+// the fittest one
+func (g *Genome) Crossover(p1, p2 *Genome) {
+	if g.conf != p1.conf {
+		g.conf = p1.conf
+		g.network = nil
+	}
+
+	g.cppn.Crossover(p1.cppn, p2.cppn)
+	g.compiled = false
+}
+
+// Mutate mutates the CPPN of the genome
+func (g *Genome) Mutate() {
+	g.cppn.Mutate()
+	g.compiled = false
+}
+
+// Reset resets the state of the generated network
+func (g *Genome) Reset() {
+	if g.compiled {
+		g.network.Reset()
+	}
+}
+
+// Clone returns a copy of the genome
+func (g *Genome) Clone() *Genome {
+	return &Genome{
+		cppn: g.cppn.Clone(),
+		conf: g.conf,
+	}
+}
+
+// Distance returns the compatibility distance between the CPPNs of both genomes
+func (g *Genome) Distance(other *Genome) float64 {
+	return g.cppn.Distance(other.cppn)
+}
+
+// Sharing returns a fitness sharing function for a population of genomes, which speciates
+// the genomes by their CPPN.
+func Sharing(s *neat.Speciation) func(genomes []*Genome, fitness []float32) {
+	var cppns []*neat.Genome
+	return func(genomes []*Genome, fitness []float32) {
+		cppns = cppns[:0]
+		for _, g := range genomes {
+			cppns = append(cppns, g.cppn)
+		}
+		s.Share(cppns, fitness)
+	}
+}
+
+// generate queries the CPPN for the weight of every connection of the substrate
+func (g *Genome) generate() {
+	layers := g.conf.Substrate.Layers
+	if len(g.weights) != len(layers)-1 {
+		g.weights = make([][]float32, len(layers)-1)
+	}
+
+	var input [6]float32
+	var output [1]float32
+	cppn := g.cppn.Network()
+	for l := 0; l < len(layers)-1; l++ {
+		src, dst := layers[l], layers[l+1]
+		weights := g.weights[l][:0]
+		input[2], input[5] = g.conf.Substrate.depth(l), g.conf.Substrate.depth(l+1)
+		for _, from := range src {
+			for _, to := range dst {
+				input[0], input[1] = from.X, from.Y
+				input[3], input[4] = to.X, to.Y
+				cppn.Predict(input[:], output[:])
+				weights = append(weights, express(output[0], g.conf.Threshold, g.conf.Scale))
+			}
+		}
+
+		g.weights[l] = weights
+	}
+}
+
+// express maps the output of the CPPN into a weight, where the outputs with a magnitude below
+// the threshold are not expressed and the others are scaled to the range of the weights.
+func express(v, threshold, scale float32) float32 {
+	switch {
+	case v >= threshold:
+		return (v - threshold) / (1 - threshold) * scale
+	case v <= -threshold:
+		return (v + threshold) / (1 - threshold) * scale
+	default:
+		return 0
+	}
+}
+
+// defaultOf returns the value, or the default value if it is zero
+func defaultOf(value, defaultValue float32) float32 {
+	if value == 0 {
+		return defaultValue
+	}
+	return value
+}
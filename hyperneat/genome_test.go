@@ -0,0 +1,150 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package hyperneat
+
+import (
+	"testing"
+
+	"github.com/kelindar/evolve"
+	"github.com/kelindar/evolve/neat"
+	"github.com/kelindar/evolve/neural"
+	"github.com/kelindar/evolve/neural/layer"
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+cpu: Intel(R) Xeon(R) Processor
+BenchmarkNetwork 	    4791	    253505 ns/op	      10 B/op	       0 allocs/op
+*/
+func BenchmarkNetwork(b *testing.B) {
+	g := New(Config{
+		Substrate: Substrate{
+			Layers: [][]Point{Grid(11, 11), Grid(5, 5), Line(4)},
+		},
+	})()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.compiled = false
+		g.Network()
+	}
+}
+
+func TestEvolve(t *testing.T) {
+	species := &neat.Speciation{Target: 5}
+	pop := evolve.New(128, evaluateSide, New(Config{
+		Substrate: Substrate{
+			Layers: [][]Point{Grid(5, 5), Line(1)},
+			Output: layer.Tanh,
+		},
+	}))
+	pop.SetSharing(Sharing(species))
+
+	var fittest *Genome
+	for i := 0; i < 200; i++ {
+		if fittest = pop.Evolve(); evaluateSide(fittest) == 1 {
+			break
+		}
+	}
+
+	assert.Equal(t, float32(1), evaluateSide(fittest))
+	assert.Greater(t, species.Len(), 0)
+}
+
+func TestNetwork(t *testing.T) {
+	g := New(Config{
+		Substrate: Substrate{
+			Layers:     [][]Point{Grid(3, 2), Line(4), Line(2)},
+			Activation: layer.ReLU,
+			Output:     layer.Sigmoid,
+		},
+	})()
+
+	nn := g.Network()
+	assert.Same(t, nn, g.Network())
+	assert.Equal(t, neural.Architecture{
+		Inputs: 6,
+		Layers: []neural.Spec{
+			{Type: neural.FFN, Size: 4, Activation: layer.ReLU},
+			{Type: neural.FFN, Size: 2, Activation: layer.Sigmoid},
+		},
+	}, nn.Architecture())
+	assert.Len(t, g.weights, 2)
+	assert.Len(t, g.weights[0], 24)
+	assert.Len(t, g.weights[1], 8)
+	for _, weights := range g.weights {
+		for _, w := range weights {
+			assert.LessOrEqual(t, w, float32(3))
+			assert.GreaterOrEqual(t, w, float32(-3))
+		}
+	}
+
+	// The weights are generated again in place once the genome changes
+	for i := 0; i < 10; i++ {
+		g.Mutate()
+	}
+
+	input := []float32{1, -1, 0.5, 0, -0.5, 1}
+	assert.Same(t, nn, g.Network())
+	expect := neural.NewNetworkFrom(nn.Architecture(), g.weights...)
+	assert.Equal(t, expect.Predict(input, nil), g.Predict(input, nil))
+
+	// A clone generates the same network
+	clone := g.Clone()
+	clone.Network()
+	assert.Equal(t, g.weights, clone.weights)
+}
+
+func TestGrid(t *testing.T) {
+	assert.Equal(t, []Point{{-1, -1}, {1, -1}, {-1, 1}, {1, 1}}, Grid(2, 2))
+	assert.Equal(t, []Point{{-1, 0}, {0, 0}, {1, 0}}, Line(3))
+	assert.Equal(t, []Point{{0, 0}}, Line(1))
+}
+
+func TestExpress(t *testing.T) {
+	assert.Equal(t, float32(0), express(0.1, 0.2, 3))
+	assert.Equal(t, float32(0), express(-0.1, 0.2, 3))
+	assert.Equal(t, float32(3), express(1, 0.2, 3))
+	assert.Equal(t, float32(-3), express(-1, 0.2, 3))
+	assert.InDelta(t, 1.5, express(0.6, 0.2, 3), 1e-6)
+}
+
+func TestInvalid(t *testing.T) {
+	assert.Panics(t, func() {
+		New(Config{})
+	})
+
+	assert.Panics(t, func() {
+		New(Config{Substrate: Substrate{Layers: [][]Point{Line(2), {}}}})
+	})
+
+	assert.Panics(t, func() {
+		New(Config{Substrate: Substrate{Layers: [][]Point{Line(2), Line(1)}}, Threshold: 1})
+	})
+}
+
+// evaluateSide evaluates whether the network tells if the left or the right half of a 5x5 grid
+// is brighter, which requires weights that vary with the horizontal coordinate.
+func evaluateSide(g *Genome) float32 {
+	correct := 0
+	input := make([]float32, 25)
+	for i := 0; i < 25; i++ {
+		x := i % 5
+		if x == 2 {
+			continue
+		}
+
+		for j := range input {
+			input[j] = 0
+		}
+		input[i] = 1
+
+		out := g.Predict(input, nil)
+		if (out[0] > 0) == (x > 2) {
+			correct++
+		}
+	}
+	return float32(correct) / 20
+}
@@ -0,0 +1,87 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package hyperneat
+
+import (
+	"fmt"
+
+	"github.com/kelindar/evolve/neural"
+	"github.com/kelindar/evolve/neural/layer"
+)
+
+// Point represents the coordinates of a neuron within its layer, typically within [-1, 1]
+type Point struct {
+	X, Y float32
+}
+
+// Substrate represents the layout of the network generated by a CPPN, where every layer is
+// fully connected to the next one. The layers are placed along the depth axis at evenly
+// spaced coordinates within [-1, 1], from the inputs to the outputs.
+type Substrate struct {
+	Layers     [][]Point        // The coordinates of the neurons of every layer, starting with the inputs
+	Activation layer.Activation // The activation of the hidden layers, or its default one
+	Output     layer.Activation // The activation of the output layer, or its default one
+}
+
+// Grid returns the coordinates of a grid of neurons, evenly spaced within [-1, 1] on both axes
+// and ordered row by row, which suits grid sensors.
+func Grid(cols, rows int) []Point {
+	out := make([]Point, 0, cols*rows)
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			out = append(out, Point{X: spread(x, cols), Y: spread(y, rows)})
+		}
+	}
+	return out
+}
+
+// Line returns the coordinates of a row of neurons, evenly spaced within [-1, 1]
+func Line(n int) []Point {
+	return Grid(n, 1)
+}
+
+// validate validates the layout of the substrate
+func (s *Substrate) validate() error {
+	if len(s.Layers) < 2 {
+		return fmt.Errorf("hyperneat: substrate requires at least 2 layers, got %d", len(s.Layers))
+	}
+
+	for i, points := range s.Layers {
+		if len(points) == 0 {
+			return fmt.Errorf("hyperneat: layer %d of the substrate is empty", i)
+		}
+	}
+	return nil
+}
+
+// architecture returns the architecture of the network, where every layer is feed-forward
+func (s *Substrate) architecture() neural.Architecture {
+	arch := neural.Architecture{Inputs: len(s.Layers[0])}
+	for i, points := range s.Layers[1:] {
+		activation := s.Activation
+		if i == len(s.Layers)-2 {
+			activation = s.Output
+		}
+
+		arch.Layers = append(arch.Layers, neural.Spec{
+			Type:       neural.FFN,
+			Size:       len(points),
+			Activation: activation,
+		})
+	}
+	return arch
+}
+
+// depth returns the coordinate of the layer along the depth axis
+func (s *Substrate) depth(i int) float32 {
+	return spread(i, len(s.Layers))
+}
+
+// spread returns the coordinate of the i-th of n evenly spaced points within [-1, 1]
+func spread(i, n int) float32 {
+	if n == 1 {
+		return 0
+	}
+	return -1 + 2*float32(i)/float32(n-1)
+}
@@ -0,0 +1,36 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package operator
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// Name returns the name of the function which created the operator, for example "NPoint"
+// for the closure returned by NPoint(2), or the fallback if the operator is not specified.
+func Name(fn any, fallback string) string {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return fallback
+	}
+
+	f := runtime.FuncForPC(v.Pointer())
+	if f == nil {
+		return fallback
+	}
+
+	// Strip the package path, the type parameters and the closure suffix
+	name := f.Name()
+	name = name[strings.LastIndexByte(name, '/')+1:]
+	name = name[strings.IndexByte(name, '.')+1:]
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.Index(name, ".func"); i >= 0 {
+		name = name[:i]
+	}
+	return strings.TrimSuffix(name, "-fm")
+}
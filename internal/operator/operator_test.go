@@ -0,0 +1,31 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package operator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mutation func(v []int)
+
+func swap(rate float64) mutation {
+	return func(v []int) {}
+}
+
+func scale[T any](factor T) func(v []T) {
+	return func(v []T) {}
+}
+
+func reverse(v []int) {}
+
+func TestName(t *testing.T) {
+	var none mutation
+	assert.Equal(t, "swap", Name(swap(0.1), "mutation"))
+	assert.Equal(t, "scale", Name(scale(2.0), "mutation"))
+	assert.Equal(t, "reverse", Name(reverse, "mutation"))
+	assert.Equal(t, "mutation", Name(none, "mutation"))
+	assert.Equal(t, "mutation", Name(nil, "mutation"))
+}
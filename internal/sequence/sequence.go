@@ -0,0 +1,64 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package sequence
+
+import (
+	"math/rand"
+)
+
+// Splice performs a cut-and-splice crossover, writing the head of the first parent up to a
+// random cut point followed by the tail of the second parent after another random cut point
+// into the destination. The length of the offspring is kept in the [min, max] range, where a
+// non-positive maximum means that the length is unbounded. If no such cut points exist, the
+// offspring is a copy of the first parent.
+func Splice[T any](dst, p1, p2 []T, min, max int) []T {
+	if max <= 0 {
+		max = len(p1) + len(p2)
+	}
+
+	for attempt := 0; attempt < 5; attempt++ {
+		c1 := rand.Intn(len(p1) + 1)
+
+		// Select the second cut point, so the length is within the bounds
+		lo, hi := len(p2)-(max-c1), len(p2)-(min-c1)
+		if lo < 0 {
+			lo = 0
+		}
+		if hi > len(p2) {
+			hi = len(p2)
+		}
+		if lo > hi {
+			continue
+		}
+
+		c2 := lo + rand.Intn(hi-lo+1)
+		dst = append(dst[:0], p1[:c1]...)
+		return append(dst, p2[c2:]...)
+	}
+
+	return append(dst[:0], p1...)
+}
+
+// Insert inserts the value at the specified index
+func Insert[T any](s []T, i int, value T) []T {
+	var zero T
+	s = append(s, zero)
+	copy(s[i+1:], s[i:])
+	s[i] = value
+	return s
+}
+
+// Delete deletes the value at the specified index
+func Delete[T any](s []T, i int) []T {
+	copy(s[i:], s[i+1:])
+	return s[:len(s)-1]
+}
+
+// Resize resizes the slice to the specified length, reusing its capacity if possible
+func Resize[T any](s []T, length int) []T {
+	if cap(s) < length {
+		return make([]T, length)
+	}
+	return s[:length]
+}
@@ -0,0 +1,58 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package sequence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplice(t *testing.T) {
+	p1 := []int{1, 1, 1, 1, 1, 1}
+	p2 := []int{2, 2, 2, 2, 2, 2, 2, 2, 2, 2}
+	for i := 0; i < 1000; i++ {
+		child := Splice(nil, p1, p2, 4, 8)
+		assert.GreaterOrEqual(t, len(child), 4)
+		assert.LessOrEqual(t, len(child), 8)
+
+		// The head comes from the first parent and the tail from the second one
+		for k := 1; k < len(child); k++ {
+			assert.LessOrEqual(t, child[k-1], child[k])
+		}
+	}
+}
+
+func TestSpliceUnbounded(t *testing.T) {
+	lengths := map[int]bool{}
+	for i := 0; i < 1000; i++ {
+		lengths[len(Splice(nil, []int{1, 1}, []int{2, 2, 2}, 0, 0))] = true
+	}
+
+	assert.Len(t, lengths, 6)
+}
+
+func TestSpliceImpossible(t *testing.T) {
+	child := Splice(nil, []int{1, 1}, []int{2, 2}, 10, 20)
+	assert.Equal(t, []int{1, 1}, child)
+}
+
+func TestInsertDelete(t *testing.T) {
+	s := []int{1, 2, 3}
+	s = Insert(s, 0, 0)
+	s = Insert(s, 4, 4)
+	s = Insert(s, 2, 9)
+	assert.Equal(t, []int{0, 1, 9, 2, 3, 4}, s)
+
+	s = Delete(s, 2)
+	s = Delete(s, 0)
+	s = Delete(s, 3)
+	assert.Equal(t, []int{1, 2, 3}, s)
+}
+
+func TestResize(t *testing.T) {
+	s := make([]int, 2, 10)
+	assert.Len(t, Resize(s, 5), 5)
+	assert.Len(t, Resize(s, 20), 20)
+}
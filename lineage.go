@@ -0,0 +1,288 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package evolve
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Origin represents how a genome entered the population
+type Origin uint8
+
+const (
+	Genesis   Origin = iota // Created randomly when the population was created or grown
+	Offspring               // Bred from two parents by crossover and mutation
+	Seeded                  // Provided by Seed
+	Injected                // Provided by Inject
+	Restarted               // Created or hypermutated by a restart
+)
+
+// String returns the name of the origin
+func (o Origin) String() string {
+	switch o {
+	case Genesis:
+		return "genesis"
+	case Offspring:
+		return "offspring"
+	case Seeded:
+		return "seeded"
+	case Injected:
+		return "injected"
+	case Restarted:
+		return "restarted"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText encodes the origin as its name
+func (o Origin) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+// UnmarshalText decodes the origin from its name
+func (o *Origin) UnmarshalText(text []byte) error {
+	for v := Genesis; v <= Restarted; v++ {
+		if v.String() == string(text) {
+			*o = v
+			return nil
+		}
+	}
+	return fmt.Errorf("evolve: unknown origin %q", text)
+}
+
+// Traced represents a genome which reports the names of the operators applied by its last
+// crossover and mutation, for example a chromosome with configurable operators. Without it,
+// the lineage records the crossover and the mutation of every offspring.
+type Traced interface {
+	Operators() []string
+}
+
+// Record represents the birth record of a single genome
+type Record struct {
+	ID         uint64   `json:"id"`                  // The unique identifier of the genome, starting at 1
+	Parents    []uint64 `json:"parents,omitempty"`   // The identifiers of the parents, if any
+	Operators  []string `json:"operators,omitempty"` // The operators applied to the parents
+	Origin     Origin   `json:"origin"`              // How the genome entered the population
+	Generation int      `json:"generation"`          // The generation the genome was born in
+	Fitness    float32  `json:"fitness"`             // The fitness of the genome, once evaluated
+}
+
+// Lineage represents the genealogy of a population, which is a graph where every genome
+// points to its parents. The records are sorted by their identifier.
+type Lineage struct {
+	Records []Record `json:"records"`
+}
+
+// Record returns the birth record of the genome with the specified identifier
+func (l *Lineage) Record(id uint64) (Record, bool) {
+	i := sort.Search(len(l.Records), func(i int) bool {
+		return l.Records[i].ID >= id
+	})
+
+	if i < len(l.Records) && l.Records[i].ID == id {
+		return l.Records[i], true
+	}
+	return Record{}, false
+}
+
+// Fittest returns the birth record of the fittest genome ever evaluated
+func (l *Lineage) Fittest() (fittest Record, ok bool) {
+	for _, r := range l.Records {
+		if !ok || r.Fitness > fittest.Fitness {
+			fittest, ok = r, true
+		}
+	}
+	return
+}
+
+// Ancestry returns the lineage of the genome with the specified identifier, which contains
+// the genome itself along with all of its ancestors.
+func (l *Lineage) Ancestry(id uint64) *Lineage {
+	seen := make(map[uint64]bool)
+	queue := []uint64{id}
+	out := new(Lineage)
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if seen[next] {
+			continue
+		}
+
+		seen[next] = true
+		if r, ok := l.Record(next); ok {
+			out.Records = append(out.Records, r)
+			queue = append(queue, r.Parents...)
+		}
+	}
+
+	sort.Slice(out.Records, func(i, j int) bool {
+		return out.Records[i].ID < out.Records[j].ID
+	})
+	return out
+}
+
+// WriteDOT writes the lineage as a graph in the DOT language, where every edge points from
+// a parent to its child and is labelled with the operators applied.
+func (l *Lineage) WriteDOT(w io.Writer) error {
+	out := bufio.NewWriter(w)
+	fmt.Fprintln(out, "digraph lineage {")
+	for _, r := range l.Records {
+		fmt.Fprintf(out, "\t%d [label=\"#%d %s\\ngen %d, fitness %g\"];\n",
+			r.ID, r.ID, r.Origin, r.Generation, r.Fitness)
+		for _, parent := range r.Parents {
+			fmt.Fprintf(out, "\t%d -> %d [label=%q];\n", parent, r.ID, strings.Join(r.Operators, ", "))
+		}
+	}
+
+	fmt.Fprintln(out, "}")
+	return out.Flush()
+}
+
+// ---------------------------------- Tracking ----------------------------------
+
+// breeding represents the operators applied to every offspring by default, which are shared
+// between the records since they are never modified
+var breeding = []string{"crossover", "mutation"}
+
+// tracker tracks the lineage of the genomes of a population
+type tracker struct {
+	records []Record // The records, indexed by their identifier minus one
+	ids     []uint64 // The identifiers of the current genomes
+	parents []uint64 // The identifiers of the last evaluated genomes
+	buffer  []uint64 // The identifiers of the offspring being bred
+}
+
+// SetLineage enables or disables the lineage tracking, which records the parents, the
+// operators and the birth generation of every genome. Since every genome ever created is
+// recorded, the memory grows with the number of evaluations.
+func (p *Population[T]) SetLineage(enabled bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !enabled {
+		p.lineage = nil
+		return
+	}
+
+	p.lineage = new(tracker)
+	p.lineage.ids = make([]uint64, len(p.genomes))
+	for i := range p.lineage.ids {
+		p.lineage.ids[i] = p.lineage.add(Genesis, p.generation)
+	}
+}
+
+// Lineage returns a snapshot of the genealogy of the population, or nil if the lineage
+// tracking is not enabled.
+func (p *Population[T]) Lineage() *Lineage {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.lineage == nil {
+		return nil
+	}
+
+	return &Lineage{
+		Records: append([]Record(nil), p.lineage.records...),
+	}
+}
+
+// add creates a new record and returns its identifier
+func (t *tracker) add(origin Origin, generation int, parents ...uint64) uint64 {
+	id := uint64(len(t.records) + 1)
+	t.records = append(t.records, Record{
+		ID:         id,
+		Parents:    parents,
+		Origin:     origin,
+		Generation: generation,
+	})
+	return id
+}
+
+// replace records a new genome of the specified origin at the specified index
+func (t *tracker) replace(i int, origin Origin, generation int) {
+	if t != nil {
+		t.ids[i] = t.add(origin, generation)
+	}
+}
+
+// resize resizes the identifiers of the current genomes, recording the new ones
+func (t *tracker) resize(n int, generation int) {
+	if t == nil {
+		return
+	}
+
+	if n <= len(t.ids) {
+		t.ids = t.ids[:n]
+		return
+	}
+
+	for len(t.ids) < n {
+		t.ids = append(t.ids, t.add(Genesis, generation))
+	}
+}
+
+// evaluated records the fitness of the current genomes
+func (t *tracker) evaluated(fitness []float32) {
+	if t == nil {
+		return
+	}
+
+	for i, id := range t.ids {
+		t.records[id-1].Fitness = fitness[i]
+	}
+}
+
+// breed records an offspring of the parents at the specified indices
+func (t *tracker) breed(i, p1, p2 int, generation int, genome any) {
+	if t == nil {
+		return
+	}
+
+	operators := breeding
+	if traced, ok := genome.(Traced); ok {
+		operators = traced.Operators()
+	}
+
+	t.buffer = append(t.buffer[:i], t.add(Offspring, generation, t.ids[p1], t.ids[p2]))
+	t.records[len(t.records)-1].Operators = operators
+}
+
+// advance makes the offspring the current genomes
+func (t *tracker) advance() {
+	if t == nil {
+		return
+	}
+
+	t.parents, t.ids, t.buffer = t.ids, t.buffer, t.parents[:0]
+}
+
+// restart records a restarted population of the specified size, which keeps the elites at
+// the specified indices at its front
+func (t *tracker) restart(elites []int, n int, generation int) {
+	if t == nil {
+		return
+	}
+
+	ids := make([]uint64, 0, n)
+	for _, i := range elites {
+		ids = append(ids, t.ids[i])
+	}
+	for len(ids) < n {
+		ids = append(ids, t.add(Restarted, generation))
+	}
+	t.ids = ids
+}
+
+// derive records a genome derived from the one at the specified index by an operator
+func (t *tracker) derive(i int, generation int, operator string) {
+	if t == nil {
+		return
+	}
+
+	t.ids[i] = t.add(Restarted, generation, t.ids[i])
+	t.records[len(t.records)-1].Operators = []string{operator}
+}
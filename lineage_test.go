@@ -0,0 +1,141 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package evolve_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kelindar/evolve"
+	"github.com/kelindar/evolve/binary"
+	"github.com/kelindar/evolve/permutation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineage(t *testing.T) {
+	const target = "hello"
+	pop := newPop(10, target)
+	assert.Nil(t, pop.Lineage())
+
+	pop.SetLineage(true)
+	seed := binary.Genome(target)
+	pop.Seed(&seed)
+	for i := 0; i < 5; i++ {
+		pop.Evolve()
+	}
+
+	lineage := pop.Lineage()
+	assert.Len(t, lineage.Records, 10+1+5*10)
+
+	// The seed is the fittest genome, and its record replaced the first genesis record
+	fittest, ok := lineage.Fittest()
+	assert.True(t, ok)
+	assert.Equal(t, float32(1), fittest.Fitness)
+
+	seeded, ok := lineage.Record(11)
+	assert.True(t, ok)
+	assert.Equal(t, evolve.Seeded, seeded.Origin)
+	assert.Equal(t, float32(1), seeded.Fitness)
+
+	// Every offspring descends from two parents of the previous generation
+	for _, r := range lineage.Records[11:] {
+		assert.Equal(t, evolve.Offspring, r.Origin)
+		assert.Equal(t, []string{"crossover", "mutation"}, r.Operators)
+		assert.Len(t, r.Parents, 2)
+		for _, id := range r.Parents {
+			parent, ok := lineage.Record(id)
+			assert.True(t, ok)
+			assert.Equal(t, r.Generation-1, parent.Generation)
+		}
+	}
+
+	// The ancestry of the last offspring reaches back to the first generation
+	last := lineage.Records[len(lineage.Records)-1]
+	ancestry := lineage.Ancestry(last.ID)
+	assert.Equal(t, 0, ancestry.Records[0].Generation)
+	assert.Equal(t, last, ancestry.Records[len(ancestry.Records)-1])
+
+	// Disabling the tracking discards the lineage
+	pop.SetLineage(false)
+	assert.Nil(t, pop.Lineage())
+}
+
+func TestLineageOperators(t *testing.T) {
+	pop := evolve.New(10, func(*binary.Chromosome) float32 { return 1 }, binary.NewChromosome(4, binary.Operators{
+		Crossover: binary.TwoPoint(),
+		Mutation:  binary.BitFlip(0.01),
+	}))
+
+	pop.SetLineage(true)
+	pop.Evolve()
+
+	// The chromosome reports the configured operators
+	for _, r := range pop.Lineage().Records[10:] {
+		assert.Equal(t, evolve.Offspring, r.Origin)
+		assert.Equal(t, []string{"NPoint", "BitFlip"}, r.Operators)
+	}
+
+	// The default operators of the genome are reported with the generic labels
+	perm := evolve.New(10, func(*permutation.Chromosome) float32 { return 1 }, permutation.NewChromosome(4, permutation.Operators{
+		Crossover: permutation.PMX(),
+	}))
+
+	perm.SetLineage(true)
+	perm.Evolve()
+	for _, r := range perm.Lineage().Records[10:] {
+		assert.Equal(t, []string{"PMX", "mutation"}, r.Operators)
+	}
+}
+
+func TestLineageRestart(t *testing.T) {
+	pop := evolve.New(10, func(*binary.Genome) float32 {
+		return 1
+	}, binary.New(5))
+
+	// The constant fitness never improves, hence the second generation stagnates
+	pop.SetLineage(true)
+	pop.SetRestart(evolve.Restart{
+		Strategy:   evolve.FullRestart,
+		Stagnation: 1,
+		Growth:     2,
+	})
+
+	pop.Evolve()
+	pop.Evolve()
+	assert.Equal(t, 1, pop.Restarts())
+
+	// The population grew to 20, keeping a single elite
+	restarted := 0
+	for _, r := range pop.Lineage().Records {
+		if r.Origin == evolve.Restarted {
+			restarted++
+		}
+	}
+	assert.Equal(t, 19, restarted)
+}
+
+func TestLineageExport(t *testing.T) {
+	pop := newPop(4, "hello")
+	pop.SetLineage(true)
+	pop.Inject(binary.New(5)())
+	pop.Evolve()
+
+	lineage := pop.Lineage()
+	out, err := json.Marshal(lineage)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), `"origin":"injected"`)
+	assert.Contains(t, string(out), `"operators":["crossover","mutation"]`)
+
+	decoded := new(evolve.Lineage)
+	assert.NoError(t, json.Unmarshal(out, decoded))
+	assert.Equal(t, lineage, decoded)
+
+	var buffer bytes.Buffer
+	assert.NoError(t, lineage.WriteDOT(&buffer))
+	assert.True(t, strings.HasPrefix(buffer.String(), "digraph lineage {\n"))
+	assert.Equal(t, 2*4, strings.Count(buffer.String(), "->"))
+	assert.Contains(t, buffer.String(), `[label="crossover, mutation"]`)
+}
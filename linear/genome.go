@@ -0,0 +1,407 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package linear
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/kelindar/evolve/internal/sequence"
+)
+
+// Config represents the configuration of a linear genome
+type Config struct {
+	Inputs       int       // The number of read-only input registers
+	Outputs      int       // The number of calculation registers holding the outputs (default: 1)
+	Registers    int       // The number of calculation registers (default: 4)
+	Constants    []float64 // The values of the read-only constant registers
+	Ops          []Op      // The instruction set (default: Add, Sub, Mul, Div)
+	MinLength    int       // The minimum number of instructions (default: 1)
+	MaxLength    int       // The maximum number of instructions (default: 64)
+	InitLength   int       // The maximum number of instructions of the initial programs (default: 10)
+	MutationRate float64   // The probability of mutating an offspring (default: 0.5)
+}
+
+// Genome represents a program as a list of register instructions
+type Genome struct {
+	code      []Instruction // The instructions of the program
+	conf      *Config       // The shared configuration
+	exec      []Instruction // The cached effective instructions
+	compiled  bool          // Whether the effective instructions are up to date
+	effective []bool        // The scratch effective flags of the instructions
+	live      []bool        // The scratch live flags of the registers
+	registers []float64     // The register file of the interpreter
+}
+
+// New creates a function for random programs with the specified configuration. The initial
+// programs have a random length between the minimum and the initial length.
+func New(config Config) func() *Genome {
+	conf := config
+	conf.Outputs = defaultOf(conf.Outputs, 1)
+	conf.Registers = defaultOf(conf.Registers, 4)
+	conf.MinLength = defaultOf(conf.MinLength, 1)
+	conf.MaxLength = defaultOf(conf.MaxLength, 64)
+	conf.InitLength = defaultOf(conf.InitLength, 10)
+	if len(conf.Ops) == 0 {
+		conf.Ops = []Op{Add, Sub, Mul, Div}
+	}
+	if conf.MutationRate == 0 {
+		conf.MutationRate = 0.5
+	}
+
+	switch {
+	case conf.Inputs < 0:
+		panic(fmt.Errorf("linear: the number of inputs must not be negative"))
+	case conf.Outputs > conf.Registers:
+		panic(fmt.Errorf("linear: the outputs must fit into %d calculation registers", conf.Registers))
+	case conf.Registers+conf.Inputs+len(conf.Constants) > 256:
+		panic(fmt.Errorf("linear: at most 256 registers are supported"))
+	case conf.MinLength > conf.MaxLength:
+		panic(fmt.Errorf("linear: the minimum length must not exceed the maximum length"))
+	}
+
+	// Clamp the initial length within the bounds
+	if conf.InitLength < conf.MinLength {
+		conf.InitLength = conf.MinLength
+	}
+	if conf.InitLength > conf.MaxLength {
+		conf.InitLength = conf.MaxLength
+	}
+
+	return func() *Genome {
+		g := &Genome{conf: &conf}
+		g.registers = make([]float64, conf.Registers+conf.Inputs+len(conf.Constants))
+		copy(g.registers[conf.Registers+conf.Inputs:], conf.Constants)
+
+		length := conf.MinLength + rand.Intn(conf.InitLength-conf.MinLength+1)
+		g.code = make([]Instruction, length)
+		for i := range g.code {
+			g.code[i] = g.randInstruction()
+		}
+		return g
+	}
+}
+
+// Len returns the number of instructions of the program
+func (g *Genome) Len() int {
+	return len(g.code)
+}
+
+// Effective returns the number of effective instructions, which are the instructions that
+// influence the outputs of the program.
+func (g *Genome) Effective() int {
+	return len(g.compile())
+}
+
+// Eval runs the program with the specified inputs and returns the output registers. The
+// calculation registers are initialized with the inputs, repeated if necessary. Only the
+// effective instructions are executed. The evaluation uses the scratch space of the genome
+// and must not be called concurrently on the same genome, and the returned slice is only
+// valid until the next evaluation.
+func (g *Genome) Eval(inputs []float64) []float64 {
+	calc := g.registers[:g.conf.Registers]
+	for i := range calc {
+		calc[i] = 0
+		if len(inputs) > 0 {
+			calc[i] = inputs[i%len(inputs)]
+		}
+	}
+
+	copy(g.registers[g.conf.Registers:g.conf.Registers+g.conf.Inputs], inputs)
+	exec(g.compile(), g.registers)
+	return g.registers[:g.conf.Outputs]
+}
+
+// Simplify removes the introns, which are the instructions that do not influence the outputs
+// of the program, as long as the program remains within the minimum length.
+func (g *Genome) Simplify() {
+	if code := g.compile(); len(code) >= g.conf.MinLength {
+		g.code = append(g.code[:0], code...)
+	}
+}
+
+// compile returns the effective instructions, computing them if necessary
+func (g *Genome) compile() []Instruction {
+	if g.compiled {
+		return g.exec
+	}
+
+	g.analyze(0)
+	g.exec = g.exec[:0]
+	for i, in := range g.code {
+		if g.effective[i] {
+			g.exec = append(g.exec, in)
+		}
+	}
+
+	g.compiled = true
+	return g.exec
+}
+
+// analyze marks the effective instructions from the end of the program down to the specified
+// position, and returns the calculation registers which are live before that position.
+// Starting with the outputs, an instruction is effective if it writes a live register, in
+// which case the register it writes is no longer live (unless a branch may skip it) and the
+// registers it reads become live. A branch is effective if the instruction it guards is.
+func (g *Genome) analyze(stop int) []bool {
+	g.effective = sequence.Resize(g.effective, len(g.code))
+	g.live = sequence.Resize(g.live, g.conf.Registers)
+	for i := range g.live {
+		g.live[i] = i < g.conf.Outputs
+	}
+
+	for i := len(g.code) - 1; i >= stop; i-- {
+		in := g.code[i]
+		switch {
+		case in.Op.Branch():
+			g.effective[i] = i+1 < len(g.code) && g.effective[i+1]
+		default:
+			g.effective[i] = g.live[in.Dst]
+			if g.effective[i] && (i == 0 || !g.code[i-1].Op.Branch()) {
+				g.live[in.Dst] = false
+			}
+		}
+
+		if g.effective[i] {
+			g.use(in.Src1)
+			if in.Op.Arity() > 1 {
+				g.use(in.Src2)
+			}
+		}
+	}
+	return g.live
+}
+
+// use marks the operand as live, if it is a calculation register
+func (g *Genome) use(operand uint8) {
+	if int(operand) < g.conf.Registers {
+		g.live[operand] = true
+	}
+}
+
+// Crossover performs a two-point linear crossover, replacing a random segment of the first
+// parent with a random segment of the second parent while respecting the length limits.
+func (g *Genome) Crossover(p1, p2 *Genome) {
+	c1, c2 := p1.code, p2.code
+	defer g.invalidate()
+	for attempt := 0; attempt < 5; attempt++ {
+		i, iEnd := randSegment(len(c1))
+		j, jEnd := randSegment(len(c2))
+		if n := len(c1) - (iEnd - i) + (jEnd - j); n < g.conf.MinLength || n > g.conf.MaxLength {
+			continue
+		}
+
+		g.code = append(g.code[:0], c1[:i]...)
+		g.code = append(g.code, c2[j:jEnd]...)
+		g.code = append(g.code, c1[iEnd:]...)
+		return
+	}
+
+	// Failed to produce a valid offspring, clone the first parent
+	g.code = append(g.code[:0], c1...)
+}
+
+// Mutate performs either a macro mutation, which inserts or deletes an instruction, or a
+// micro mutation, which changes the operation or a register of an effective instruction.
+func (g *Genome) Mutate() {
+	if rand.Float64() >= g.conf.MutationRate {
+		return
+	}
+
+	defer g.invalidate()
+	switch rand.Intn(4) {
+	case 0:
+		g.mutateInsert()
+	case 1:
+		g.mutateDelete()
+	default:
+		g.mutateInstruction()
+	}
+}
+
+// mutateInsert inserts a random instruction which writes a register that is live at its
+// position, so that the inserted instruction is effective.
+func (g *Genome) mutateInsert() {
+	if len(g.code) >= g.conf.MaxLength {
+		g.mutateInstruction()
+		return
+	}
+
+	i := rand.Intn(len(g.code) + 1)
+	in := g.randInstruction()
+	if live := g.analyze(i); !in.Op.Branch() {
+		if dst, ok := randLive(live); ok {
+			in.Dst = dst
+		}
+	}
+
+	g.code = sequence.Insert(g.code, i, in)
+}
+
+// mutateDelete deletes a random instruction, preferring the effective ones
+func (g *Genome) mutateDelete() {
+	if len(g.code) <= g.conf.MinLength || len(g.code) == 0 {
+		g.mutateInstruction()
+		return
+	}
+
+	g.code = sequence.Delete(g.code, g.point())
+}
+
+// mutateInstruction changes either the operation, the destination or one of the operands of
+// a random instruction, preferring the effective ones
+func (g *Genome) mutateInstruction() {
+	if len(g.code) == 0 {
+		return
+	}
+
+	in := &g.code[g.point()]
+	switch rand.Intn(3) {
+	case 0:
+		in.Op = g.conf.Ops[rand.Intn(len(g.conf.Ops))]
+	case 1:
+		in.Dst = uint8(rand.Intn(g.conf.Registers))
+	default:
+		if rand.Intn(2) == 0 {
+			in.Src1 = g.randOperand(false)
+		} else {
+			in.Src2 = g.randOperand(true)
+		}
+	}
+}
+
+// Reset resets the internal state, no-op in this case
+func (g *Genome) Reset() {
+	// No state
+}
+
+// Clone returns a copy of the program, sharing the same configuration
+func (g *Genome) Clone() *Genome {
+	return &Genome{
+		code:      append([]Instruction(nil), g.code...),
+		conf:      g.conf,
+		registers: append([]float64(nil), g.registers...),
+	}
+}
+
+// String returns the program as a list of instructions, where the introns are commented out
+func (g *Genome) String() string {
+	if g == nil {
+		return "<nil>"
+	}
+
+	g.analyze(0)
+	var sb strings.Builder
+	for i, in := range g.code {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		if !g.effective[i] {
+			sb.WriteString("// ")
+		}
+		g.format(&sb, in)
+	}
+	return sb.String()
+}
+
+// format writes the instruction into the builder
+func (g *Genome) format(sb *strings.Builder, in Instruction) {
+	switch {
+	case in.Op.Branch():
+		fmt.Fprintf(sb, "if %s %s %s", g.operand(in.Src1), in.Op, g.operand(in.Src2))
+	case in.Op.Arity() == 1:
+		fmt.Fprintf(sb, "r%d = %s(%s)", in.Dst, in.Op, g.operand(in.Src1))
+	default:
+		fmt.Fprintf(sb, "r%d = %s %s %s", in.Dst, g.operand(in.Src1), in.Op, g.operand(in.Src2))
+	}
+}
+
+// operand returns the name of a register, or the value of a constant
+func (g *Genome) operand(operand uint8) string {
+	switch i := int(operand); {
+	case i < g.conf.Registers:
+		return "r" + strconv.Itoa(i)
+	case i < g.conf.Registers+g.conf.Inputs:
+		return "x" + strconv.Itoa(i-g.conf.Registers)
+	default:
+		return strconv.FormatFloat(g.conf.Constants[i-g.conf.Registers-g.conf.Inputs], 'g', 4, 64)
+	}
+}
+
+// invalidate marks the cached effective instructions as stale
+func (g *Genome) invalidate() {
+	g.compiled = false
+}
+
+// ---------------------------------- Generation ----------------------------------
+
+// randInstruction creates a random instruction, where only the second operand may be a constant
+func (g *Genome) randInstruction() Instruction {
+	return Instruction{
+		Op:   g.conf.Ops[rand.Intn(len(g.conf.Ops))],
+		Dst:  uint8(rand.Intn(g.conf.Registers)),
+		Src1: g.randOperand(false),
+		Src2: g.randOperand(true),
+	}
+}
+
+// randOperand selects a random calculation or input register, or a constant if allowed
+func (g *Genome) randOperand(constant bool) uint8 {
+	n := g.conf.Registers + g.conf.Inputs
+	if constant && len(g.conf.Constants) > 0 && rand.Intn(2) == 0 {
+		return uint8(n + rand.Intn(len(g.conf.Constants)))
+	}
+	return uint8(rand.Intn(n))
+}
+
+// point selects a random instruction, biased towards the effective instructions (90%)
+func (g *Genome) point() int {
+	if rand.Float64() < 0.9 {
+		g.analyze(0)
+		if i, ok := randTrue(g.effective); ok {
+			return i
+		}
+	}
+
+	return rand.Intn(len(g.code))
+}
+
+// randSegment selects a random non-empty segment, or an empty one if the length is zero
+func randSegment(length int) (int, int) {
+	if length == 0 {
+		return 0, 0
+	}
+
+	i := rand.Intn(length)
+	return i, i + 1 + rand.Intn(length-i)
+}
+
+// randLive selects a random live register
+func randLive(live []bool) (uint8, bool) {
+	i, ok := randTrue(live)
+	return uint8(i), ok
+}
+
+// randTrue selects a random index with a true value using reservoir sampling
+func randTrue(flags []bool) (index int, ok bool) {
+	count := 0
+	for i, flag := range flags {
+		if flag {
+			if count++; rand.Intn(count) == 0 {
+				index, ok = i, true
+			}
+		}
+	}
+	return
+}
+
+// defaultOf returns the default value if the value is not set
+func defaultOf(value, defaultValue int) int {
+	if value <= 0 {
+		return defaultValue
+	}
+	return value
+}
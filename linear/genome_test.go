@@ -0,0 +1,208 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package linear
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kelindar/evolve"
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+cpu: Intel(R) Xeon(R) Processor
+BenchmarkEval 	29940324	       118.0 ns/op	       0 B/op	       0 allocs/op
+*/
+func BenchmarkEval(b *testing.B) {
+	g := New(Config{
+		Inputs:     1,
+		Constants:  []float64{1},
+		Ops:        []Op{Add, Sub, Mul, Div, IfGreater},
+		InitLength: 64,
+		MinLength:  64,
+	})()
+
+	inputs := []float64{0.5}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		g.Eval(inputs)
+	}
+}
+
+func TestEvolve(t *testing.T) {
+	pop := evolve.New(512, evaluate, New(Config{
+		Inputs:    1,
+		Constants: []float64{1},
+	}))
+
+	// Evolve
+	var last *Genome
+	for i := 0; i < 200; i++ {
+		if last = pop.Evolve(); evaluate(last) > 0.999 {
+			break
+		}
+	}
+
+	assert.Greater(t, evaluate(last), float32(0.999), last.String())
+}
+
+func TestEval(t *testing.T) {
+	g := program(Config{
+		Inputs:    2,
+		Registers: 2,
+		Constants: []float64{2},
+	},
+		Instruction{Op: Mul, Dst: 1, Src1: 2, Src2: 4}, // r1 = x0 * 2
+		Instruction{Op: IfGreater, Src1: 3, Src2: 1},   // if x1 > r1
+		Instruction{Op: Add, Dst: 1, Src1: 1, Src2: 3}, // r1 = r1 + x1
+		Instruction{Op: Sin, Dst: 0, Src1: 1},          // r0 = sin(r1)
+		Instruction{Op: Div, Dst: 0, Src1: 1, Src2: 4}, // r0 = r1 / 2
+		Instruction{Op: Sub, Dst: 1, Src1: 0, Src2: 0}, // r1 = r0 - r0
+		Instruction{Op: IfLess, Src1: 0, Src2: 4},      // if r0 < 2
+		Instruction{Op: IfLess, Src1: 0, Src2: 2},      // if r0 < x0
+		Instruction{Op: Exp, Dst: 0, Src1: 2},          // r0 = exp(x0)
+	)
+
+	assert.Equal(t, 9, g.Len())
+	assert.Equal(t, 7, g.Effective())
+	assert.Equal(t, []float64{3}, g.Eval([]float64{3, 1}))
+	assert.Equal(t, []float64{5}, g.Eval([]float64{2, 6}))
+	assert.Equal(t, []float64{math.Exp(-2)}, g.Eval([]float64{-2, -1}))
+	assert.Equal(t, "r1 = x0 * 2\n"+
+		"if x1 > r1\n"+
+		"r1 = r1 + x1\n"+
+		"// r0 = sin(r1)\n"+
+		"r0 = r1 / 2\n"+
+		"// r1 = r0 - r0\n"+
+		"if r0 < 2\n"+
+		"if r0 < x0\n"+
+		"r0 = exp(x0)", g.String())
+
+	g.Simplify()
+	assert.Equal(t, 7, g.Len())
+	assert.Equal(t, []float64{math.Exp(-2)}, g.Eval([]float64{-2, -1}))
+}
+
+func TestEffective(t *testing.T) {
+	conf := Config{Inputs: 1, Registers: 3}
+
+	// The branch is not effective, since it guards an intron
+	assert.Equal(t, 1, program(conf,
+		Instruction{Op: Add, Dst: 0, Src1: 3, Src2: 3},
+		Instruction{Op: IfGreater, Src1: 0, Src2: 3},
+		Instruction{Op: Add, Dst: 2, Src1: 3, Src2: 3},
+	).Effective())
+
+	// Overwritten registers are not effective, unless the overwrite may be skipped
+	assert.Equal(t, 1, program(conf,
+		Instruction{Op: Add, Dst: 0, Src1: 3, Src2: 3},
+		Instruction{Op: Mul, Dst: 0, Src1: 3, Src2: 3},
+	).Effective())
+	assert.Equal(t, 3, program(conf,
+		Instruction{Op: Add, Dst: 0, Src1: 3, Src2: 3},
+		Instruction{Op: IfGreater, Src1: 3, Src2: 3},
+		Instruction{Op: Mul, Dst: 0, Src1: 3, Src2: 3},
+	).Effective())
+
+	// The registers read by an effective instruction become effective
+	assert.Equal(t, 3, program(conf,
+		Instruction{Op: Add, Dst: 1, Src1: 3, Src2: 3},
+		Instruction{Op: Add, Dst: 2, Src1: 3, Src2: 3},
+		Instruction{Op: Sub, Dst: 0, Src1: 1, Src2: 2},
+	).Effective())
+}
+
+func TestOperators(t *testing.T) {
+	genesis := New(Config{
+		Inputs:       2,
+		Registers:    6,
+		Constants:    []float64{-1, 0.5},
+		Ops:          []Op{Add, Sub, Mul, Div, Sin, Cos, Exp, Log, IfGreater, IfLess},
+		MinLength:    4,
+		MaxLength:    32,
+		MutationRate: 1,
+	})
+
+	child := genesis()
+	for i := 0; i < 1000; i++ {
+		child.Crossover(genesis(), genesis())
+		child.Mutate()
+		child.Reset()
+
+		assert.GreaterOrEqual(t, child.Len(), 4)
+		assert.LessOrEqual(t, child.Len(), 32)
+		assert.LessOrEqual(t, child.Effective(), child.Len())
+		assert.NotPanics(t, func() {
+			child.Eval([]float64{0.5, 2})
+		})
+	}
+}
+
+func TestInsertEffective(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		code := []Instruction{
+			{Op: Add, Dst: 1, Src1: 1, Src2: 8},
+			{Op: Add, Dst: 0, Src1: 0, Src2: 1},
+		}
+
+		g := program(Config{Inputs: 1, Registers: 8, Ops: []Op{Add}}, code...)
+		g.mutateInsert()
+		g.analyze(0)
+
+		// Find the inserted instruction, which must be effective
+		at := 0
+		for at < len(code) && g.code[at] == code[at] {
+			at++
+		}
+		assert.True(t, g.effective[at], g.String())
+	}
+}
+
+func TestClone(t *testing.T) {
+	g := New(Config{Inputs: 1, Constants: []float64{1, 2}, MinLength: 5})()
+	clone := g.Clone()
+	assert.Equal(t, g.String(), clone.String())
+	assert.Equal(t, g.Eval([]float64{0.5}), clone.Eval([]float64{0.5}))
+
+	clone.code[0].Op = clone.code[0].Op ^ 1
+	assert.NotEqual(t, g.code, clone.code)
+}
+
+func TestInvalidConfig(t *testing.T) {
+	assert.Panics(t, func() {
+		New(Config{Inputs: -1})
+	})
+	assert.Panics(t, func() {
+		New(Config{Outputs: 5, Registers: 4})
+	})
+	assert.Panics(t, func() {
+		New(Config{Inputs: 255, Registers: 4})
+	})
+	assert.Panics(t, func() {
+		New(Config{MinLength: 10, MaxLength: 5})
+	})
+}
+
+// program creates a genome with the specified instructions
+func program(config Config, code ...Instruction) *Genome {
+	g := New(config)()
+	g.code = code
+	g.invalidate()
+	return g
+}
+
+// evaluate evaluates the program against x^2 + x + 1
+func evaluate(g *Genome) float32 {
+	var errors float64
+	for x := -1.0; x <= 1.0; x += 0.1 {
+		errors += math.Abs(g.Eval([]float64{x})[0] - (x*x + x + 1))
+	}
+
+	if math.IsNaN(errors) {
+		return 0
+	}
+	return float32(1 / (1 + errors))
+}
@@ -0,0 +1,129 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package linear
+
+import (
+	"math"
+)
+
+// Op represents an operation of a register instruction
+type Op uint8
+
+// Supported operations
+const (
+	Add       Op = iota // r[dst] = a + b
+	Sub                 // r[dst] = a - b
+	Mul                 // r[dst] = a * b
+	Div                 // r[dst] = a / b, protected so that a division by zero returns 1
+	Sin                 // r[dst] = sin(a)
+	Cos                 // r[dst] = cos(a)
+	Exp                 // r[dst] = e^a, capped to avoid overflowing
+	Log                 // r[dst] = ln|a|, protected so that the logarithm of zero returns 0
+	IfGreater           // executes the next instruction only if a > b
+	IfLess              // executes the next instruction only if a < b
+)
+
+// Arity returns the number of operands read by the operation
+func (op Op) Arity() int {
+	switch op {
+	case Sin, Cos, Exp, Log:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Branch returns whether the operation is a conditional branch
+func (op Op) Branch() bool {
+	return op == IfGreater || op == IfLess
+}
+
+// String returns the symbol of the operation
+func (op Op) String() string {
+	switch op {
+	case Add:
+		return "+"
+	case Sub:
+		return "-"
+	case Mul:
+		return "*"
+	case Div:
+		return "/"
+	case Sin:
+		return "sin"
+	case Cos:
+		return "cos"
+	case Exp:
+		return "exp"
+	case Log:
+		return "log"
+	case IfGreater:
+		return ">"
+	case IfLess:
+		return "<"
+	default:
+		return "?"
+	}
+}
+
+// Instruction represents a single register instruction. The operands index the register
+// file of the program, which contains the calculation registers followed by the read-only
+// input and constant registers. The destination is always a calculation register.
+type Instruction struct {
+	Op   Op    // The operation
+	Dst  uint8 // The destination register, unused by the branches
+	Src1 uint8 // The first operand
+	Src2 uint8 // The second operand, unused by the unary operations
+}
+
+// exec executes the code on the register file. When a branch condition does not hold, the
+// next instruction is skipped, and consecutive branches therefore act as a conjunction.
+func exec(code []Instruction, r []float64) {
+	for pc := 0; pc < len(code); pc++ {
+		in := &code[pc]
+		a, b := r[in.Src1], r[in.Src2]
+		switch in.Op {
+		case Add:
+			r[in.Dst] = a + b
+		case Sub:
+			r[in.Dst] = a - b
+		case Mul:
+			r[in.Dst] = a * b
+		case Div:
+			if math.Abs(b) < 1e-9 {
+				r[in.Dst] = 1
+				continue
+			}
+			r[in.Dst] = a / b
+		case Sin:
+			r[in.Dst] = math.Sin(a)
+		case Cos:
+			r[in.Dst] = math.Cos(a)
+		case Exp:
+			r[in.Dst] = math.Exp(math.Min(a, 100))
+		case Log:
+			if math.Abs(a) < 1e-9 {
+				r[in.Dst] = 0
+				continue
+			}
+			r[in.Dst] = math.Log(math.Abs(a))
+		case IfGreater:
+			if !(a > b) {
+				pc = skip(code, pc)
+			}
+		case IfLess:
+			if !(a < b) {
+				pc = skip(code, pc)
+			}
+		}
+	}
+}
+
+// skip returns the position of the instruction skipped by a branch at the specified position,
+// which is the first instruction after the chain of branches that follows it.
+func skip(code []Instruction, pc int) int {
+	for pc++; pc < len(code) && code[pc].Op.Branch(); pc++ {
+	}
+	return pc
+}
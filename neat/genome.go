@@ -0,0 +1,493 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package neat
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// Config represents the configuration of a NEAT genome
+type Config struct {
+	Inputs       int                     // The number of inputs
+	Outputs      int                     // The number of outputs (default: 1)
+	Activation   func(float32) float32   // The activation of the hidden and output nodes (default: steepened sigmoid)
+	Activations  []func(float32) float32 // The alternative activations which the hidden nodes can select (default: none)
+	Recurrent    bool                    // Whether recurrent connections can be added
+	AddNode      float64                 // The probability of adding a node (default: 0.03)
+	AddLink      float64                 // The probability of adding a connection (default: 0.05)
+	WeightRate   float64                 // The probability of mutating the weights (default: 0.8)
+	WeightPower  float64                 // The standard deviation of the weight perturbations (default: 0.5)
+	Toggle       float64                 // The probability of toggling a connection (default: 0.01)
+	Switch       float64                 // The probability of switching the activation of a hidden node (default: 0.05)
+	Interspecies float64                 // The probability of mating across species (default: 0.001)
+	Excess       float64                 // The compatibility coefficient of the excess genes (default: 1)
+	Disjoint     float64                 // The compatibility coefficient of the disjoint genes (default: 1)
+	Weight       float64                 // The compatibility coefficient of the weight differences (default: 0.4)
+}
+
+// Kind represents the kind of a node
+type Kind uint8
+
+// Supported node kinds
+const (
+	Input Kind = iota
+	Bias
+	Output
+	Hidden
+)
+
+// Node represents a node gene
+type Node struct {
+	ID         int  // The identifier of the node
+	Kind       Kind // The kind of the node
+	Activation int  // The activation of the node, where 0 is the default one and i > 0 is Config.Activations[i-1]
+}
+
+// Link represents a connection gene
+type Link struct {
+	Innovation int     // The innovation number of the connection
+	In         int     // The identifier of the source node
+	Out        int     // The identifier of the target node
+	Weight     float32 // The weight of the connection
+	Enabled    bool    // Whether the connection is expressed
+}
+
+// Genome represents a neural network whose topology is evolved along with its weights,
+// starting from a minimal topology where the inputs are directly connected to the outputs.
+type Genome struct {
+	nodes    []Node   // The node genes, sorted by their identifier
+	links    []Link   // The connection genes, sorted by their innovation number
+	conf     *config  // The shared configuration
+	network  *Network // The cached phenotype
+	compiled bool     // Whether the phenotype is up to date
+	species  int      // The species assigned by the speciation, if any
+}
+
+// config represents the configuration shared by the genomes of a population
+type config struct {
+	Config
+	registry  *innovations
+	functions []func(float32) float32 // The default activation, followed by the alternative ones
+}
+
+// New creates a function for minimal genomes with the specified configuration, where every
+// input and the bias are connected to every output with a random weight. The genomes created
+// by the same function share the registry of the structural innovations.
+func New(c Config) func() *Genome {
+	conf := &config{Config: c}
+	conf.Outputs = defaultOf(conf.Outputs, 1)
+	conf.AddNode = defaultOf(conf.AddNode, 0.03)
+	conf.AddLink = defaultOf(conf.AddLink, 0.05)
+	conf.WeightRate = defaultOf(conf.WeightRate, 0.8)
+	conf.WeightPower = defaultOf(conf.WeightPower, 0.5)
+	conf.Toggle = defaultOf(conf.Toggle, 0.01)
+	conf.Switch = defaultOf(conf.Switch, 0.05)
+	conf.Interspecies = defaultOf(conf.Interspecies, 0.001)
+	conf.Excess = defaultOf(conf.Excess, 1)
+	conf.Disjoint = defaultOf(conf.Disjoint, 1)
+	conf.Weight = defaultOf(conf.Weight, 0.4)
+	if conf.Activation == nil {
+		conf.Activation = Sigmoid
+	}
+
+	switch {
+	case conf.Inputs <= 0:
+		panic(fmt.Errorf("neat: the number of inputs must be positive, got %d", conf.Inputs))
+	case conf.Outputs < 0:
+		panic(fmt.Errorf("neat: the number of outputs must be positive, got %d", conf.Outputs))
+	}
+
+	conf.functions = append([]func(float32) float32{conf.Activation}, conf.Activations...)
+	for i, fn := range conf.Activations {
+		if fn == nil {
+			panic(fmt.Errorf("neat: activation %d is nil", i))
+		}
+	}
+
+	// The inputs, the bias and the outputs are reserved as the first nodes
+	conf.registry = newInnovations(conf.Inputs + 1 + conf.Outputs)
+	return func() *Genome {
+		g := &Genome{conf: conf, species: -1}
+		for i := 0; i < conf.Inputs+1+conf.Outputs; i++ {
+			g.nodes = append(g.nodes, Node{ID: i, Kind: conf.kindOf(i)})
+		}
+
+		for out := conf.Inputs + 1; out < len(g.nodes); out++ {
+			for in := 0; in <= conf.Inputs; in++ {
+				g.links = append(g.links, Link{
+					Innovation: conf.registry.link(in, out),
+					In:         in,
+					Out:        out,
+					Weight:     randWeight(),
+					Enabled:    true,
+				})
+			}
+		}
+
+		sortLinks(g.links)
+		return g
+	}
+}
+
+// kindOf returns the kind of one of the reserved nodes
+func (c *config) kindOf(id int) Kind {
+	switch {
+	case id < c.Inputs:
+		return Input
+	case id == c.Inputs:
+		return Bias
+	case id <= c.Inputs+c.Outputs:
+		return Output
+	default:
+		return Hidden
+	}
+}
+
+// Nodes returns the node genes of the genome, which must not be modified
+func (g *Genome) Nodes() []Node {
+	return g.nodes
+}
+
+// Links returns the connection genes of the genome, which must not be modified
+func (g *Genome) Links() []Link {
+	return g.links
+}
+
+// Predict evaluates the network with the specified inputs and writes its outputs. Since the
+// network can be recurrent, it keeps its state between the calls until it is reset.
+func (g *Genome) Predict(input, output []float32) []float32 {
+	return g.Network().Predict(input, output)
+}
+
+// Network returns the phenotype of the genome, which is compiled when the genome changes
+func (g *Genome) Network() *Network {
+	if !g.compiled {
+		g.network = compile(g, g.network)
+		g.compiled = true
+	}
+	return g.network
+}
+
+// Reset resets the recurrent state of the network
+func (g *Genome) Reset() {
+	if g.compiled {
+		g.network.Reset()
+	}
+}
+
+// Clone returns a copy of the genome
+func (g *Genome) Clone() *Genome {
+	return &Genome{
+		nodes:   append([]Node(nil), g.nodes...),
+		links:   append([]Link(nil), g.links...),
+		conf:    g.conf,
+		species: g.species,
+	}
+}
+
+// String returns the enabled connections of the genome
+func (g *Genome) String() string {
+	var sb strings.Builder
+	for _, link := range g.links {
+		if link.Enabled {
+			fmt.Fprintf(&sb, "%d -> %d (%.3f)\n", link.In, link.Out, link.Weight)
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// ---------------------------------- Crossover ----------------------------------
+
+// Crossover aligns the connection genes of both parents by their innovation number, where
+// the matching genes are inherited randomly and the disjoint and excess genes are inherited
+// from the first parent, which is the fittest one. If the parents belong to different species,
+// the offspring is usually a copy of the first parent.
+func (g *Genome) Crossover(p1, p2 *Genome) {
+	g.conf = p1.conf
+	g.species = p1.species
+	g.compiled = false
+	g.links = g.links[:0]
+	if p1.species != p2.species && rand.Float64() >= g.conf.Interspecies {
+		g.links = append(g.links, p1.links...)
+		g.nodes = append(g.nodes[:0], p1.nodes...)
+		return
+	}
+
+	for i, j := 0, 0; i < len(p1.links); i++ {
+		link := p1.links[i]
+		for j < len(p2.links) && p2.links[j].Innovation < link.Innovation {
+			j++
+		}
+
+		// Inherit the matching genes randomly, and keep them disabled if disabled in either
+		if j < len(p2.links) && p2.links[j].Innovation == link.Innovation {
+			other := p2.links[j]
+			if rand.Intn(2) == 0 {
+				link.Weight = other.Weight
+			}
+			if !link.Enabled || !other.Enabled {
+				link.Enabled = rand.Float64() >= 0.75
+			}
+		}
+
+		g.links = append(g.links, link)
+	}
+
+	// Inherit the nodes of the first parent, since the structure comes from it
+	g.nodes = append(g.nodes[:0], p1.nodes...)
+}
+
+// ---------------------------------- Mutation ----------------------------------
+
+// Mutate mutates the weights and the topology of the genome
+func (g *Genome) Mutate() {
+	if rand.Float64() < g.conf.AddNode {
+		g.mutateAddNode()
+	}
+
+	if rand.Float64() < g.conf.AddLink {
+		g.mutateAddLink()
+	}
+
+	if rand.Float64() < g.conf.WeightRate {
+		g.mutateWeights()
+	}
+
+	if rand.Float64() < g.conf.Toggle && len(g.links) > 0 {
+		i := rand.Intn(len(g.links))
+		g.links[i].Enabled = !g.links[i].Enabled
+	}
+
+	if len(g.conf.Activations) > 0 && rand.Float64() < g.conf.Switch {
+		g.mutateActivation()
+	}
+
+	g.compiled = false
+}
+
+// mutateWeights perturbs every weight, or occasionally replaces it with a random one
+func (g *Genome) mutateWeights() {
+	for i := range g.links {
+		if rand.Float64() < 0.1 {
+			g.links[i].Weight = randWeight()
+			continue
+		}
+
+		w := float64(g.links[i].Weight) + rand.NormFloat64()*g.conf.WeightPower
+		g.links[i].Weight = float32(math.Max(-8, math.Min(8, w)))
+	}
+}
+
+// mutateAddNode splits a random enabled connection with a new node, where the incoming
+// connection has a weight of one and the outgoing one keeps the weight of the connection.
+func (g *Genome) mutateAddNode() {
+	i, ok := g.randLink()
+	if !ok {
+		return
+	}
+
+	split := g.links[i]
+	node := g.conf.registry.split(split.Innovation)
+	if g.hasNode(node) {
+		return // The connection was already split once
+	}
+
+	g.links[i].Enabled = false
+	g.links = append(g.links, Link{
+		Innovation: g.conf.registry.link(split.In, node),
+		In:         split.In,
+		Out:        node,
+		Weight:     1,
+		Enabled:    true,
+	}, Link{
+		Innovation: g.conf.registry.link(node, split.Out),
+		In:         node,
+		Out:        split.Out,
+		Weight:     split.Weight,
+		Enabled:    true,
+	})
+
+	g.nodes = append(g.nodes, Node{ID: node, Kind: Hidden, Activation: g.conf.randActivation()})
+	sort.Slice(g.nodes, func(i, j int) bool {
+		return g.nodes[i].ID < g.nodes[j].ID
+	})
+	sortLinks(g.links)
+}
+
+// mutateAddLink connects two random nodes which are not connected yet. Unless the recurrent
+// connections are allowed, the connections which would create a cycle are rejected.
+func (g *Genome) mutateAddLink() {
+	const attempts = 20
+	for k := 0; k < attempts; k++ {
+		in := g.nodes[rand.Intn(len(g.nodes))]
+		out := g.nodes[rand.Intn(len(g.nodes))]
+		switch {
+		case out.Kind == Input || out.Kind == Bias:
+			continue
+		case !g.conf.Recurrent && (in.Kind == Output || g.reaches(out.ID, in.ID)):
+			continue
+		case g.hasLink(in.ID, out.ID):
+			continue
+		}
+
+		g.links = append(g.links, Link{
+			Innovation: g.conf.registry.link(in.ID, out.ID),
+			In:         in.ID,
+			Out:        out.ID,
+			Weight:     randWeight(),
+			Enabled:    true,
+		})
+		sortLinks(g.links)
+		return
+	}
+}
+
+// mutateActivation switches the activation of a random hidden node, if any
+func (g *Genome) mutateActivation() {
+	offset := rand.Intn(len(g.nodes))
+	for k := range g.nodes {
+		if i := (offset + k) % len(g.nodes); g.nodes[i].Kind == Hidden {
+			g.nodes[i].Activation = g.conf.randActivation()
+			return
+		}
+	}
+}
+
+// randActivation selects a random activation for a new hidden node, which is always the
+// default one unless alternative activations are configured
+func (c *config) randActivation() int {
+	if len(c.Activations) == 0 {
+		return 0
+	}
+	return rand.Intn(len(c.functions))
+}
+
+// randLink selects a random enabled connection
+func (g *Genome) randLink() (int, bool) {
+	offset := rand.Intn(len(g.links) + 1)
+	for k := range g.links {
+		if i := (offset + k) % len(g.links); g.links[i].Enabled {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// hasNode checks whether the genome contains the node
+func (g *Genome) hasNode(id int) bool {
+	return g.find(id) < len(g.nodes)
+}
+
+// find returns the index of the node, or the number of nodes if the genome does not contain it
+func (g *Genome) find(id int) int {
+	i := sort.Search(len(g.nodes), func(i int) bool {
+		return g.nodes[i].ID >= id
+	})
+	if i < len(g.nodes) && g.nodes[i].ID == id {
+		return i
+	}
+	return len(g.nodes)
+}
+
+// hasLink checks whether the genome contains a connection between the nodes
+func (g *Genome) hasLink(in, out int) bool {
+	for _, link := range g.links {
+		if link.In == in && link.Out == out {
+			return true
+		}
+	}
+	return false
+}
+
+// reaches checks whether there is a path from one node to another, including through the
+// disabled connections since they can be enabled again later on.
+func (g *Genome) reaches(from, to int) bool {
+	visited := map[int]bool{from: true}
+	stack := []int{from}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if node == to {
+			return true
+		}
+
+		for _, link := range g.links {
+			if link.In == node && !visited[link.Out] {
+				visited[link.Out] = true
+				stack = append(stack, link.Out)
+			}
+		}
+	}
+	return false
+}
+
+// ---------------------------------- Distance ----------------------------------
+
+// Distance returns the compatibility distance between the two genomes, which is a weighted
+// sum of the number of excess and disjoint genes, normalized by the size of the larger genome,
+// and of the mean weight difference of the matching genes.
+func (g *Genome) Distance(other *Genome) float64 {
+	var excess, disjoint, matching int
+	var weights float64
+
+	i, j := 0, 0
+	for i < len(g.links) && j < len(other.links) {
+		a, b := g.links[i], other.links[j]
+		switch {
+		case a.Innovation == b.Innovation:
+			weights += math.Abs(float64(a.Weight - b.Weight))
+			matching++
+			i++
+			j++
+		case a.Innovation < b.Innovation:
+			disjoint++
+			i++
+		default:
+			disjoint++
+			j++
+		}
+	}
+	excess = len(g.links) - i + len(other.links) - j
+
+	// Small genomes are not normalized, as in the original NEAT
+	n := len(g.links)
+	if len(other.links) > n {
+		n = len(other.links)
+	}
+	if n < 20 {
+		n = 1
+	}
+
+	distance := (g.conf.Excess*float64(excess) + g.conf.Disjoint*float64(disjoint)) / float64(n)
+	if matching > 0 {
+		distance += g.conf.Weight * weights / float64(matching)
+	}
+	return distance
+}
+
+// ---------------------------------- Helpers ----------------------------------
+
+// sortLinks sorts the connections by their innovation number
+func sortLinks(links []Link) {
+	sort.Slice(links, func(i, j int) bool {
+		return links[i].Innovation < links[j].Innovation
+	})
+}
+
+// randWeight returns a random weight within [-2, 2)
+func randWeight() float32 {
+	return rand.Float32()*4 - 2
+}
+
+// defaultOf returns the value, or the default value if it is zero
+func defaultOf[T int | float64](value, defaultValue T) T {
+	if value == 0 {
+		return defaultValue
+	}
+	return value
+}
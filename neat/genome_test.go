@@ -0,0 +1,306 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package neat
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kelindar/evolve"
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+cpu: Intel(R) Xeon(R) Processor
+BenchmarkPredict 	  327252	      4710 ns/op	       0 B/op	       0 allocs/op
+*/
+func BenchmarkPredict(b *testing.B) {
+	g := New(Config{Inputs: 10, Recurrent: true, AddNode: 1, AddLink: 1})()
+	for i := 0; i < 100; i++ {
+		g.Mutate()
+	}
+
+	in := make([]float32, 10)
+	out := make([]float32, 1)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.Predict(in, out)
+	}
+}
+
+func TestXOR(t *testing.T) {
+	species := &Speciation{Target: 8}
+	pop := evolve.New(256, evaluateXOR, New(Config{Inputs: 2}))
+	pop.SetSharing(species.Share)
+
+	var fittest *Genome
+	for i := 0; i < 500; i++ {
+		if fittest = pop.Evolve(); solvesXOR(fittest) {
+			break
+		}
+	}
+
+	assert.True(t, solvesXOR(fittest))
+	assert.Greater(t, species.Len(), 0)
+}
+
+func TestNew(t *testing.T) {
+	g := New(Config{Inputs: 3, Outputs: 2})()
+	assert.Len(t, g.Nodes(), 6)
+	assert.Len(t, g.Links(), 8)
+	assert.Equal(t, []Node{
+		{ID: 0, Kind: Input}, {ID: 1, Kind: Input}, {ID: 2, Kind: Input}, {ID: 3, Kind: Bias}, {ID: 4, Kind: Output}, {ID: 5, Kind: Output},
+	}, g.Nodes())
+
+	for i, link := range g.Links() {
+		assert.Equal(t, i, link.Innovation)
+		assert.True(t, link.Enabled)
+	}
+
+	assert.Panics(t, func() {
+		New(Config{})
+	})
+}
+
+func TestAddNode(t *testing.T) {
+	fn := New(Config{Inputs: 1})
+	g1, g2 := fn(), fn()
+	g1.mutateAddNode()
+	g2.mutateAddNode()
+
+	// The same split in different genomes must result in the same innovations
+	assert.Len(t, g1.Nodes(), 4)
+	assert.Equal(t, Node{ID: 3, Kind: Hidden}, g1.Nodes()[3])
+	assert.Len(t, g1.Links(), 4)
+	if g1.Links()[2].In == g2.Links()[2].In {
+		for i := range g1.Links() {
+			assert.Equal(t, g1.Links()[i].Innovation, g2.Links()[i].Innovation)
+			assert.Equal(t, g1.Links()[i].Out, g2.Links()[i].Out)
+		}
+	}
+
+	// The split connection is disabled, and the new node preserves its weight
+	var disabled Link
+	for _, link := range g1.Links() {
+		if !link.Enabled {
+			disabled = link
+		}
+	}
+	assert.Equal(t, float32(1), g1.Links()[2].Weight)
+	assert.Equal(t, disabled.Weight, g1.Links()[3].Weight)
+	assert.Equal(t, disabled.In, g1.Links()[2].In)
+	assert.Equal(t, disabled.Out, g1.Links()[3].Out)
+}
+
+func TestAddLink(t *testing.T) {
+	g := New(Config{Inputs: 1})()
+	for i := 0; i < 20; i++ {
+		g.mutateAddNode()
+	}
+	for i := 0; i < 100; i++ {
+		g.mutateAddLink()
+	}
+
+	// Without recurrent connections, the outputs must never feed back into the network
+	for _, link := range g.Links() {
+		assert.False(t, g.reaches(link.Out, link.In), link)
+	}
+
+	// With recurrent connections, cycles can be created
+	r := New(Config{Inputs: 1, Recurrent: true})()
+	for i := 0; i < 20; i++ {
+		r.mutateAddNode()
+	}
+	for i := 0; i < 100; i++ {
+		r.mutateAddLink()
+	}
+
+	cycles := 0
+	for _, link := range r.Links() {
+		if r.reaches(link.Out, link.In) {
+			cycles++
+		}
+	}
+	assert.Greater(t, cycles, 0)
+}
+
+func TestCrossover(t *testing.T) {
+	fn := New(Config{Inputs: 2})
+	p1, p2 := fn(), fn()
+	p1.mutateAddNode()
+	p2.mutateAddLink()
+	p2.mutateAddNode()
+	p2.mutateAddNode()
+
+	child := fn()
+	child.Crossover(p1, p2)
+
+	// The structure is inherited from the first parent
+	assert.Equal(t, p1.Nodes(), child.Nodes())
+	assert.Equal(t, len(p1.Links()), len(child.Links()))
+	for i, link := range child.Links() {
+		assert.Equal(t, p1.Links()[i].Innovation, link.Innovation)
+		assert.Equal(t, p1.Links()[i].In, link.In)
+		assert.Equal(t, p1.Links()[i].Out, link.Out)
+	}
+
+	// The matching genes come from either parent
+	for i := 0; i < 3; i++ {
+		w := child.Links()[i].Weight
+		assert.True(t, w == p1.Links()[i].Weight || w == p2.Links()[i].Weight)
+	}
+}
+
+func TestCrossoverSpecies(t *testing.T) {
+	fn := New(Config{Inputs: 2})
+	p1, p2 := fn(), fn()
+	p1.species, p2.species = 0, 1
+
+	child := fn()
+	child.Crossover(p1, p2)
+	assert.Equal(t, p1.Links(), child.Links())
+	assert.Equal(t, 0, child.species)
+}
+
+func TestDistance(t *testing.T) {
+	fn := New(Config{Inputs: 2})
+	g1 := fn()
+	g2 := g1.Clone()
+	assert.Equal(t, 0.0, g1.Distance(g2))
+
+	// Weight differences of the matching genes are averaged
+	for i := range g2.links {
+		g2.links[i].Weight += 1
+	}
+	assert.InDelta(t, 0.4, g1.Distance(g2), 1e-6)
+
+	// Every excess gene adds to the distance, since the genomes are small
+	g2 = g1.Clone()
+	g2.mutateAddNode()
+	assert.InDelta(t, 2.0, g1.Distance(g2), 1e-6)
+	assert.InDelta(t, 2.0, g2.Distance(g1), 1e-6)
+}
+
+func TestPredict(t *testing.T) {
+	g := New(Config{Inputs: 2, Activation: identity})()
+	for i := range g.links {
+		g.links[i].Weight = float32(i + 1)
+	}
+
+	// 1*x + 2*y + 3*bias
+	assert.Equal(t, []float32{8}, g.Predict([]float32{1, 2}, nil))
+
+	// Splitting with the identity does not change the output
+	g.mutateAddNode()
+	g.compiled = false
+	assert.Equal(t, []float32{8}, g.Predict([]float32{1, 2}, nil))
+}
+
+func TestActivations(t *testing.T) {
+	g := New(Config{Inputs: 1, Activation: identity, Activations: []func(float32) float32{Abs}, Switch: 1})()
+	g.links[0].Weight, g.links[1].Enabled = 1, false
+
+	// The hidden node splits the input link and selects its activation, while the output keeps the default one
+	g.mutateAddNode()
+	for g.nodes[3].Activation != 1 {
+		g.mutateActivation()
+	}
+
+	g.compiled = false
+	assert.Equal(t, []float32{2}, g.Predict([]float32{-2}, nil))
+	assert.Equal(t, 0, g.nodes[2].Activation)
+
+	// The activation is inherited from the first parent
+	child := g.Clone()
+	child.Crossover(g, g.Clone())
+	assert.Equal(t, 1, child.nodes[3].Activation)
+
+	assert.Panics(t, func() {
+		New(Config{Inputs: 1, Activations: []func(float32) float32{nil}})
+	})
+
+	assert.InDelta(t, 1, Gaussian(0), 1e-6)
+	assert.InDelta(t, 1, Sine(math.Pi/2), 1e-6)
+	assert.InDelta(t, 0.5, Sigmoid(0), 1e-6)
+	assert.InDelta(t, math.Tanh(1), Tanh(1), 1e-6)
+}
+
+func TestPredictRecurrent(t *testing.T) {
+	g := New(Config{Inputs: 1, Activation: identity, Recurrent: true})()
+	g.links[0].Weight = 1
+	g.links[1].Weight = 0
+
+	// Add a self-connection on the output, which accumulates the inputs
+	g.links = append(g.links, Link{Innovation: g.conf.registry.link(2, 2), In: 2, Out: 2, Weight: 1, Enabled: true})
+	out := make([]float32, 1)
+	for i := 1; i <= 3; i++ {
+		g.Predict([]float32{1}, out)
+		assert.Equal(t, float32(i), out[0])
+	}
+
+	g.Reset()
+	g.Predict([]float32{1}, out)
+	assert.Equal(t, float32(1), out[0])
+}
+
+func TestSpeciation(t *testing.T) {
+	fn := New(Config{Inputs: 2})
+	g1 := fn()
+	g2 := g1.Clone()
+	g3 := g1.Clone()
+	for i := 0; i < 5; i++ {
+		g3.mutateAddNode()
+	}
+
+	s := &Speciation{}
+	fitness := []float32{1, 1, 1}
+	s.Share([]*Genome{g1, g2, g3}, fitness)
+	assert.Equal(t, 2, s.Len())
+	assert.Equal(t, []float32{0.5, 0.5, 1}, fitness)
+	assert.Equal(t, 0, g1.species)
+	assert.Equal(t, 0, g2.species)
+	assert.Equal(t, 1, g3.species)
+
+	// Empty species are dropped
+	fitness = []float32{1}
+	s.Share([]*Genome{g3}, fitness)
+	assert.Equal(t, 1, s.Len())
+	assert.Equal(t, 0, g3.species)
+}
+
+func TestString(t *testing.T) {
+	g := New(Config{Inputs: 1})()
+	g.links[0].Weight = 1
+	g.links[1].Weight = -1
+	assert.Equal(t, "0 -> 2 (1.000)\n1 -> 2 (-1.000)", g.String())
+}
+
+func identity(x float32) float32 {
+	return x
+}
+
+func solvesXOR(g *Genome) bool {
+	g.Reset()
+	cases := [][3]float32{{0, 0, 0}, {0, 1, 1}, {1, 0, 1}, {1, 1, 0}}
+	for _, c := range cases {
+		if out := g.Predict(c[:2], nil); (out[0] > 0.5) != (c[2] > 0.5) {
+			return false
+		}
+	}
+	return true
+}
+
+func evaluateXOR(g *Genome) float32 {
+	cases := [][3]float32{{0, 0, 0}, {0, 1, 1}, {1, 0, 1}, {1, 1, 0}}
+	out := make([]float32, 1)
+	var err float32
+	for _, c := range cases {
+		g.Predict(c[:2], out)
+		err += float32(math.Abs(float64(out[0] - c[2])))
+	}
+
+	// Squared to amplify the differences between good solutions, as in the original NEAT
+	return (4 - err) * (4 - err) / 16
+}
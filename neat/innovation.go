@@ -0,0 +1,59 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package neat
+
+import (
+	"sync"
+)
+
+// innovations represents the registry of the structural innovations of a population, which
+// assigns the same innovation number to the same structural mutation in different genomes,
+// so that their genes can be aligned during crossover.
+type innovations struct {
+	mu     sync.Mutex
+	nodes  int            // The next node identifier
+	next   int            // The next innovation number
+	links  map[[2]int]int // The innovation numbers of the connections
+	splits map[int]int    // The nodes created by splitting the connections
+}
+
+// newInnovations creates a new registry, where the first nodes are reserved
+func newInnovations(reserved int) *innovations {
+	return &innovations{
+		nodes:  reserved,
+		links:  make(map[[2]int]int),
+		splits: make(map[int]int),
+	}
+}
+
+// link returns the innovation number of the connection between two nodes
+func (r *innovations) link(in, out int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := [2]int{in, out}
+	if id, ok := r.links[key]; ok {
+		return id
+	}
+
+	id := r.next
+	r.next++
+	r.links[key] = id
+	return id
+}
+
+// split returns the identifier of the node created by splitting the connection
+func (r *innovations) split(innovation int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if id, ok := r.splits[innovation]; ok {
+		return id
+	}
+
+	id := r.nodes
+	r.nodes++
+	r.splits[innovation] = id
+	return id
+}
@@ -0,0 +1,155 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package neat
+
+import "math"
+
+// Network represents the phenotype of a genome, which is a compiled graph where the nodes
+// are evaluated in a topological order and their incoming connections are stored contiguously.
+// A connection pointing backwards in that order is recurrent, and reads the value its source
+// node had at the previous step.
+type Network struct {
+	inputs      int                     // The number of inputs
+	values      []float32               // The values of the nodes, with the inputs and the bias first
+	order       []int                   // The nodes to evaluate, in order
+	offsets     []int                   // The offsets of the incoming connections of every node in order
+	sources     []int                   // The source nodes of the incoming connections
+	weights     []float32               // The weights of the incoming connections
+	outputs     []int                   // The output nodes
+	activations []func(float32) float32 // The activation function of every node in order
+}
+
+// compile compiles the genome into a network, reusing the buffers of the previous one if any
+func compile(g *Genome, nn *Network) *Network {
+	if nn == nil {
+		nn = new(Network)
+	}
+
+	conf := g.conf
+	nn.inputs = conf.Inputs
+	nn.order = nn.order[:0]
+	nn.activations = nn.activations[:0]
+	nn.offsets = append(nn.offsets[:0], 0)
+	nn.sources = nn.sources[:0]
+	nn.weights = nn.weights[:0]
+	nn.outputs = nn.outputs[:0]
+
+	// Group the enabled incoming connections of every node
+	incoming := make(map[int][]Link, len(g.nodes))
+	for _, link := range g.links {
+		if link.Enabled {
+			incoming[link.Out] = append(incoming[link.Out], link)
+		}
+	}
+
+	// The inputs and the bias come first, in the same order as the identifiers
+	slot := make(map[int]int, len(g.nodes))
+	for id := 0; id <= conf.Inputs; id++ {
+		slot[id] = id
+	}
+
+	// Order the nodes in post-order of a depth-first search from the outputs along the
+	// incoming connections, so that the nodes which do not contribute are skipped
+	visiting := make(map[int]bool, len(g.nodes))
+	var visit func(id int)
+	visit = func(id int) {
+		if _, ok := slot[id]; ok || visiting[id] {
+			return // Already ordered, or a recurrent connection
+		}
+
+		visiting[id] = true
+		for _, link := range incoming[id] {
+			visit(link.In)
+		}
+
+		slot[id] = conf.Inputs + 1 + len(nn.order)
+		nn.order = append(nn.order, id)
+		if i := g.find(id); i < len(g.nodes) {
+			nn.activations = append(nn.activations, conf.functions[g.nodes[i].Activation])
+		} else {
+			nn.activations = append(nn.activations, conf.Activation)
+		}
+	}
+
+	for id := conf.Inputs + 1; id <= conf.Inputs+conf.Outputs; id++ {
+		visit(id)
+		nn.outputs = append(nn.outputs, slot[id])
+	}
+
+	// Store the incoming connections of every node contiguously
+	for i, id := range nn.order {
+		for _, link := range incoming[id] {
+			nn.sources = append(nn.sources, slot[link.In])
+			nn.weights = append(nn.weights, link.Weight)
+		}
+
+		nn.order[i] = slot[id]
+		nn.offsets = append(nn.offsets, len(nn.sources))
+	}
+
+	size := conf.Inputs + 1 + len(nn.order)
+	if cap(nn.values) < size {
+		nn.values = make([]float32, size)
+	}
+	nn.values = nn.values[:size]
+	nn.Reset()
+	return nn
+}
+
+// Predict evaluates a single step of the network with the specified inputs and writes its
+// outputs. If the output is nil, a new slice is allocated.
+func (nn *Network) Predict(input, output []float32) []float32 {
+	if output == nil {
+		output = make([]float32, len(nn.outputs))
+	}
+
+	copy(nn.values[:nn.inputs], input)
+	nn.values[nn.inputs] = 1
+	for i, node := range nn.order {
+		sum := float32(0)
+		for j := nn.offsets[i]; j < nn.offsets[i+1]; j++ {
+			sum += nn.weights[j] * nn.values[nn.sources[j]]
+		}
+		nn.values[node] = nn.activations[i](sum)
+	}
+
+	for i, node := range nn.outputs {
+		output[i] = nn.values[node]
+	}
+	return output
+}
+
+// Reset resets the recurrent state of the network
+func (nn *Network) Reset() {
+	for i := range nn.values {
+		nn.values[i] = 0
+	}
+}
+
+// ---------------------------------- Activations ----------------------------------
+
+// Sigmoid computes a steepened sigmoid, as in the original NEAT
+func Sigmoid(x float32) float32 {
+	return float32(1 / (1 + math.Exp(-4.9*float64(x))))
+}
+
+// Tanh computes the hyperbolic tangent
+func Tanh(x float32) float32 {
+	return float32(math.Tanh(float64(x)))
+}
+
+// Sine computes the sine, which produces repetition when used by a CPPN
+func Sine(x float32) float32 {
+	return float32(math.Sin(float64(x)))
+}
+
+// Gaussian computes e^-x², which produces symmetry when used by a CPPN
+func Gaussian(x float32) float32 {
+	return float32(math.Exp(-float64(x * x)))
+}
+
+// Abs computes the absolute value, which produces symmetry when used by a CPPN
+func Abs(x float32) float32 {
+	return float32(math.Abs(float64(x)))
+}
@@ -0,0 +1,74 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package neat
+
+// Speciation represents the speciation of a population by the compatibility distance, which
+// protects the new topologies by sharing the fitness within every species. It is meant to
+// be used as the fitness sharing function of a population.
+type Speciation struct {
+	Threshold float64 // The compatibility threshold (default: 3)
+	Target    int     // The target number of species, which adjusts the threshold if positive
+	species   []*Genome
+}
+
+// Len returns the number of species found by the last speciation
+func (s *Speciation) Len() int {
+	return len(s.species)
+}
+
+// Share assigns every genome to the first species whose representative is compatible with
+// it, creating new species as needed, and divides the fitness of every genome by the size
+// of its species. Since the representatives are taken from the previous generation, the
+// species keep their identity across generations.
+func (s *Speciation) Share(genomes []*Genome, fitness []float32) {
+	if s.Threshold == 0 {
+		s.Threshold = 3
+	}
+
+	// Assign every genome to a species
+	sizes := make([]int, len(s.species))
+	for _, g := range genomes {
+		g.species = -1
+		for i, repr := range s.species {
+			if g.Distance(repr) < s.Threshold {
+				g.species = i
+				break
+			}
+		}
+
+		if g.species < 0 {
+			g.species = len(s.species)
+			s.species = append(s.species, g.Clone())
+			sizes = append(sizes, 0)
+		}
+		sizes[g.species]++
+	}
+
+	// Drop the empty species and pick new representatives among the members
+	index := make([]int, len(s.species))
+	alive := s.species[:0]
+	for i := range s.species {
+		index[i] = len(alive)
+		if sizes[i] > 0 {
+			alive = append(alive, nil)
+		}
+	}
+	s.species = alive
+
+	for i, g := range genomes {
+		fitness[i] /= float32(sizes[g.species])
+		if g.species = index[g.species]; s.species[g.species] == nil {
+			s.species[g.species] = g.Clone()
+		}
+	}
+
+	// Adjust the threshold towards the target number of species
+	switch {
+	case s.Target <= 0:
+	case len(s.species) > s.Target:
+		s.Threshold += 0.3
+	case len(s.species) < s.Target && s.Threshold > 0.3:
+		s.Threshold -= 0.3
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+#include <stdint.h>
+#include <immintrin.h>
+
+// Computes e^x, with the input clamped so that the result remains within the range of normal
+// numbers. The remainder of the range reduction is approximated with a polynomial, as in cephes.
+static inline __m256 exp256(__m256 x) {
+    x = _mm256_min_ps(x, _mm256_set1_ps(88.0f));
+    x = _mm256_max_ps(x, _mm256_set1_ps(-87.0f));
+
+    // Reduce the range, so that e^x = 2^n * e^r
+    __m256 n = _mm256_round_ps(_mm256_mul_ps(x, _mm256_set1_ps(1.44269504088896341f)), _MM_FROUND_TO_NEAREST_INT | _MM_FROUND_NO_EXC);
+    x = _mm256_fnmadd_ps(n, _mm256_set1_ps(0.693359375f), x);
+    x = _mm256_fnmadd_ps(n, _mm256_set1_ps(-2.12194440e-4f), x);
+
+    __m256 p = _mm256_set1_ps(1.9875691500e-4f);
+    p = _mm256_fmadd_ps(p, x, _mm256_set1_ps(1.3981999507e-3f));
+    p = _mm256_fmadd_ps(p, x, _mm256_set1_ps(8.3334519073e-3f));
+    p = _mm256_fmadd_ps(p, x, _mm256_set1_ps(4.1665795894e-2f));
+    p = _mm256_fmadd_ps(p, x, _mm256_set1_ps(1.6666665459e-1f));
+    p = _mm256_fmadd_ps(p, x, _mm256_set1_ps(5.0000001201e-1f));
+    p = _mm256_fmadd_ps(p, _mm256_mul_ps(x, x), x);
+    p = _mm256_add_ps(p, _mm256_set1_ps(1.0f));
+
+    // Build 2^n from the exponent bits
+    __m256i e = _mm256_add_epi32(_mm256_cvtps_epi32(n), _mm256_set1_epi32(127));
+    return _mm256_mul_ps(p, _mm256_castsi256_ps(_mm256_slli_epi32(e, 23)));
+}
+
+// Computes 1 / (1 + e^-x)
+static inline __m256 sigmoid256(__m256 x) {
+    __m256 one = _mm256_set1_ps(1.0f);
+    __m256 e = exp256(_mm256_xor_ps(x, _mm256_set1_ps(-0.0f)));
+    return _mm256_div_ps(one, _mm256_add_ps(e, one));
+}
+
+// Computes ln(x) for values within [1, 2], using the series of 2·atanh(s) where
+// s = (x - 1) / (x + 1), which converges quickly on this range.
+static inline __m256 log256(__m256 x) {
+    __m256 one = _mm256_set1_ps(1.0f);
+    __m256 s = _mm256_div_ps(_mm256_sub_ps(x, one), _mm256_add_ps(x, one));
+    __m256 s2 = _mm256_mul_ps(s, s);
+
+    __m256 p = _mm256_set1_ps(1.0f / 9);
+    p = _mm256_fmadd_ps(p, s2, _mm256_set1_ps(1.0f / 7));
+    p = _mm256_fmadd_ps(p, s2, _mm256_set1_ps(1.0f / 5));
+    p = _mm256_fmadd_ps(p, s2, _mm256_set1_ps(1.0f / 3));
+    p = _mm256_fmadd_ps(p, s2, one);
+    p = _mm256_mul_ps(p, s);
+    return _mm256_add_ps(p, p);
+}
+
+extern "C" void f32_relu(float *x, const uint64_t n) {
+    __m256 zero = _mm256_setzero_ps();
+    for (uint64_t i = 0; (i + 7) < n; i += 8) {
+        _mm256_storeu_ps(x + i, _mm256_max_ps(_mm256_loadu_ps(x + i), zero));
+    }
+}
+
+extern "C" void f32_lrelu(float *x, const uint64_t n, const float *slope) {
+    __m256 zero = _mm256_setzero_ps();
+    __m256 a = _mm256_set1_ps(*slope);
+    for (uint64_t i = 0; (i + 7) < n; i += 8) {
+        __m256 v = _mm256_loadu_ps(x + i);
+        __m256 neg = _mm256_cmp_ps(v, zero, _CMP_LT_OQ);
+        _mm256_storeu_ps(x + i, _mm256_blendv_ps(v, _mm256_mul_ps(v, a), neg));
+    }
+}
+
+extern "C" void f32_exp(float *x, const uint64_t n) {
+    for (uint64_t i = 0; (i + 7) < n; i += 8) {
+        _mm256_storeu_ps(x + i, exp256(_mm256_loadu_ps(x + i)));
+    }
+}
+
+extern "C" void f32_sigmoid(float *x, const uint64_t n) {
+    for (uint64_t i = 0; (i + 7) < n; i += 8) {
+        _mm256_storeu_ps(x + i, sigmoid256(_mm256_loadu_ps(x + i)));
+    }
+}
+
+// Computes 2·sigmoid(2x) - 1
+extern "C" void f32_tanh(float *x, const uint64_t n) {
+    __m256 one = _mm256_set1_ps(1.0f);
+    for (uint64_t i = 0; (i + 7) < n; i += 8) {
+        __m256 v = _mm256_loadu_ps(x + i);
+        __m256 s = sigmoid256(_mm256_add_ps(v, v));
+        _mm256_storeu_ps(x + i, _mm256_sub_ps(_mm256_add_ps(s, s), one));
+    }
+}
+
+// Computes x·sigmoid(x)
+extern "C" void f32_swish(float *x, const uint64_t n) {
+    for (uint64_t i = 0; (i + 7) < n; i += 8) {
+        __m256 v = _mm256_loadu_ps(x + i);
+        _mm256_storeu_ps(x + i, _mm256_mul_ps(v, sigmoid256(v)));
+    }
+}
+
+// Computes x·sigmoid(1.5957691·(x + 0.044715·x³)), the tanh approximation of GELU
+extern "C" void f32_gelu(float *x, const uint64_t n) {
+    __m256 k = _mm256_set1_ps(1.5957691f);
+    __m256 c = _mm256_set1_ps(0.044715f);
+    for (uint64_t i = 0; (i + 7) < n; i += 8) {
+        __m256 v = _mm256_loadu_ps(x + i);
+        __m256 u = _mm256_fmadd_ps(_mm256_mul_ps(_mm256_mul_ps(v, v), c), v, v);
+        _mm256_storeu_ps(x + i, _mm256_mul_ps(v, sigmoid256(_mm256_mul_ps(u, k))));
+    }
+}
+
+// Computes max(x, 0) + ln(1 + e^-|x|)
+extern "C" void f32_softplus(float *x, const uint64_t n) {
+    __m256 zero = _mm256_setzero_ps();
+    __m256 one = _mm256_set1_ps(1.0f);
+    __m256 sign = _mm256_set1_ps(-0.0f);
+    for (uint64_t i = 0; (i + 7) < n; i += 8) {
+        __m256 v = _mm256_loadu_ps(x + i);
+        __m256 e = exp256(_mm256_or_ps(v, sign));
+        _mm256_storeu_ps(x + i, _mm256_add_ps(_mm256_max_ps(v, zero), log256(_mm256_add_ps(e, one))));
+    }
+}
@@ -4,8 +4,8 @@
 #include <stdint.h>
 #include <immintrin.h>
 
-extern "C" void f32_axpy(const float *x, float *y, const uint64_t size, const float alpha) {
-    __m256 a = _mm256_set1_ps(alpha);
+extern "C" void f32_axpy(const float *x, float *y, const uint64_t size, const float *alpha) {
+    __m256 a = _mm256_set1_ps(*alpha);
     for (uint64_t i = 0; (i + 7) < size; i += 8) {
         __m256 y_vec = _mm256_loadu_ps(y + i);
         __m256 x_vec = _mm256_loadu_ps(x + i);
@@ -17,7 +17,7 @@ extern "C" void f32_axpy(const float *x, float *y, const uint64_t size, const fl
     uint64_t tail = size % 8;
     if (tail > 0) {
         for (uint64_t i = size - tail; i < size; i++) {
-            y[i] += alpha * x[i];
+            y[i] += *alpha * x[i];
         }
     }
 }
@@ -26,7 +26,7 @@ extern "C" void f32_matmul(float *output, float *m, float *n,
                 uint64_t mr, uint64_t mc, uint64_t nr, uint64_t nc) {
     for (uint64_t i = 0; i < mr; i++) {
         for (uint64_t k = 0; k < mc; k++) {
-            f32_axpy(n + k*nc, output + i*nc, nc, m[i*mc+k]);
+            f32_axpy(n + k*nc, output + i*nc, nc, &m[i*mc+k]);
         }
     }
 }
@@ -14,13 +14,15 @@
 # -O3: enables level 3 optimization for the compiled code
 PATH="$PATH:./bin"
 
-function build_avx2_amd64 {
-    SRC="avx2_amd64.cpp"
-    ASM="avx2_amd64.s"
+# build compiles the C source into the Go assembly of the math32 package, which must declare
+# the functions with the same name and arguments in the .go file of the same name
+function build {
+    SRC="$1.cpp"
+    ASM="$1.s"
     clang-15 -S -o $ASM $SRC \
-        -mavx2 \ 
+        -mavx2 \
         -masm=intel \
-        -mllvm -inline-threshold=1000
+        -mllvm -inline-threshold=1000 \
         -mfma \
         -mstackrealign \
         -mno-red-zone \
@@ -29,8 +31,17 @@ function build_avx2_amd64 {
         -fno-rtti \
         -ffast-math \
         -O3
-    c2goasm -a -f $ASM ../$ASM
+
+    # The Go stack is only 8-byte aligned and c2goasm does not realign it, so the spills of
+    # the vector registers must not assume an aligned stack
+    sed -i -E 's/vmovaps(\s+[^#]*\[rsp)/vmovups\1/' $ASM
+    c2goasm -a -f $ASM ../math32/$ASM
     rm $ASM
+
+    # Go only saves the frame pointer of the functions with a frame, so reserve one for the
+    # functions which address their constants through BP
+    perl -0pi -e 's/(TEXT [^\n]*)\$0-(\d+)((?:(?!\nTEXT)[\s\S])*?, BP\n)/$1\$8-$2$3/g' ../math32/$ASM
 }
 
-build_avx2_amd64
\ No newline at end of file
+build avx2_amd64
+build activation_amd64
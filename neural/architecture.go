@@ -0,0 +1,131 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package neural
+
+import (
+	"fmt"
+
+	"github.com/kelindar/evolve/neural/layer"
+)
+
+// Type represents the type of a layer
+type Type string
+
+// Supported layer types
+const (
+	FFN  Type = "ffn"  // A feed-forward layer, without any recurrent state
+	RNN  Type = "rnn"  // An independently recurrent layer
+	MGU  Type = "mgu"  // A minimal gated recurrent unit layer
+	LSTM Type = "lstm" // A long short-term memory layer
+	GRU  Type = "gru"  // A gated recurrent unit layer
+)
+
+// Spec describes a single layer of a network
+type Spec struct {
+	Type       Type             `json:"type"`                 // The type of the layer
+	Size       int              `json:"size"`                 // The number of outputs of the layer
+	Activation layer.Activation `json:"activation,omitempty"` // The activation of the layer, or its default one
+	Slope      float32          `json:"slope,omitempty"`      // The slope of the leaky ReLU (default: 0.01)
+	Dense      bool             `json:"dense,omitempty"`      // Whether the recurrent matrices are dense (RNN and MGU only)
+}
+
+// Architecture describes the layers of a network, which can mix stateless feed-forward
+// layers with recurrent ones.
+type Architecture struct {
+	Inputs int    `json:"inputs"` // The number of inputs of the network
+	Layers []Spec `json:"layers"` // The hidden and output layers of the network
+}
+
+// Shape returns the number of inputs followed by the size of every layer
+func (a *Architecture) Shape() []int {
+	shape := make([]int, 0, len(a.Layers)+1)
+	shape = append(shape, a.Inputs)
+	for _, spec := range a.Layers {
+		shape = append(shape, spec.Size)
+	}
+	return shape
+}
+
+// build creates the layers of the architecture, after validating it
+func (a *Architecture) build() ([]node, error) {
+	if a.Inputs <= 0 {
+		return nil, fmt.Errorf("neural: number of inputs must be positive, got %d", a.Inputs)
+	}
+
+	if len(a.Layers) == 0 {
+		return nil, fmt.Errorf("neural: architecture requires at least one layer")
+	}
+
+	layers := make([]node, 0, len(a.Layers))
+	prev := a.Inputs
+	for i, spec := range a.Layers {
+		if spec.Size <= 0 {
+			return nil, fmt.Errorf("neural: size of layer %d must be positive, got %d", i, spec.Size)
+		}
+
+		if spec.Activation > layer.Softmax {
+			return nil, fmt.Errorf("neural: unknown activation %d of layer %d", spec.Activation, i)
+		}
+
+		l, err := newLayer(spec, prev)
+		if err != nil {
+			return nil, err
+		}
+
+		layers = append(layers, l)
+		prev = spec.Size
+	}
+	return layers, nil
+}
+
+// architectureOf returns the architecture of the layers
+func architectureOf(inputs int, layers []node) Architecture {
+	arch := Architecture{
+		Inputs: inputs,
+		Layers: make([]Spec, 0, len(layers)),
+	}
+
+	for _, l := range layers {
+		arch.Layers = append(arch.Layers, l.spec)
+	}
+	return arch
+}
+
+// newLayer creates a new layer of the specified type, with the activation of the specification
+func newLayer(spec Spec, inputSize int) (node, error) {
+	if spec.Dense && spec.Type != RNN && spec.Type != MGU {
+		return node{}, fmt.Errorf("neural: layer type %q does not support dense recurrent matrices", spec.Type)
+	}
+
+	switch spec.Type {
+	case FFN:
+		l := layer.NewFFN(inputSize, spec.Size)
+		l.Activation, l.Slope = spec.Activation, spec.Slope
+		return bind(l, spec), nil
+	case RNN:
+		l := layer.NewRNN(inputSize, spec.Size)
+		if spec.Dense {
+			l = layer.NewDenseRNN(inputSize, spec.Size)
+		}
+		l.Activation, l.Slope = spec.Activation, spec.Slope
+		return bind(l, spec), nil
+	case MGU:
+		l := layer.NewMGU(inputSize, spec.Size)
+		if spec.Dense {
+			l = layer.NewDenseMGU(inputSize, spec.Size)
+		}
+		l.Activation, l.Slope = spec.Activation, spec.Slope
+		return bind(l, spec), nil
+	case LSTM:
+		l := layer.NewLSTM(inputSize, spec.Size)
+		l.Activation, l.Slope = spec.Activation, spec.Slope
+		return bind(l, spec), nil
+	case GRU:
+		l := layer.NewGRU(inputSize, spec.Size)
+		l.Activation, l.Slope = spec.Activation, spec.Slope
+		return bind(l, spec), nil
+	default:
+		return node{}, fmt.Errorf("neural: unsupported layer type %q", spec.Type)
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package neural
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kelindar/evolve/neural/layer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchitecture(t *testing.T) {
+	arch := Architecture{
+		Inputs: 3,
+		Layers: []Spec{
+			{Type: FFN, Size: 8},
+			{Type: RNN, Size: 4},
+			{Type: MGU, Size: 4},
+			{Type: LSTM, Size: 5},
+			{Type: GRU, Size: 3},
+			{Type: FFN, Size: 2},
+		},
+	}
+
+	nn := NewNetworkFrom(arch)
+	assert.Equal(t, arch, nn.Architecture())
+	assert.Equal(t, []int{3, 8, 4, 4, 5, 3, 2}, arch.Shape())
+	assert.IsType(t, &layer.FFN{}, nn.layers[0].Layer)
+	assert.IsType(t, &layer.RNN{}, nn.layers[1].Layer)
+	assert.IsType(t, &layer.MGU{}, nn.layers[2].Layer)
+	assert.IsType(t, &layer.LSTM{}, nn.layers[3].Layer)
+	assert.IsType(t, &layer.GRU{}, nn.layers[4].Layer)
+	assert.Len(t, nn.Predict([]float32{1, 2, 3}, nil), 2)
+
+	// The architecture is part of the serialized model
+	out, err := json.Marshal(nn)
+	assert.NoError(t, err)
+
+	decoded := new(Network)
+	assert.NoError(t, json.Unmarshal(out, decoded))
+	assert.Equal(t, arch, decoded.Architecture())
+
+	// Mixed networks can be cloned and bred
+	child := nn.Clone()
+	child.Crossover(nn, NewNetworkFrom(arch))
+	child.Mutate()
+	assert.Equal(t, arch, child.Architecture())
+}
+
+func TestArchitectureCrossoverMismatch(t *testing.T) {
+	ffn := Architecture{Inputs: 2, Layers: []Spec{{Type: FFN, Size: 2}}}
+	mgu := Architecture{Inputs: 2, Layers: []Spec{{Type: MGU, Size: 2}}}
+
+	// The layer of the first parent is copied when the second parent has another type
+	child, p1, p2 := NewNetworkFrom(ffn), NewNetworkFrom(ffn), NewNetworkFrom(mgu)
+	child.Crossover(p1, p2)
+	assert.Equal(t, p1.layers[0].Weights(), child.layers[0].Weights())
+
+	// The layer is kept when the first parent has another type
+	before := child.Clone()
+	child.Crossover(p2, p1)
+	assert.Equal(t, before.layers[0].Weights(), child.layers[0].Weights())
+}
+
+func TestArchitectureActivation(t *testing.T) {
+	arch := Architecture{
+		Inputs: 2,
+		Layers: []Spec{
+			{Type: FFN, Size: 8, Activation: layer.LeakyReLU, Slope: 0.2},
+			{Type: MGU, Size: 8, Activation: layer.GELU},
+			{Type: FFN, Size: 3, Activation: layer.Softmax},
+		},
+	}
+
+	nn := NewNetworkFrom(arch)
+	out := nn.Predict([]float32{0.5, -1}, nil)
+	assert.InDelta(t, 1, out[0]+out[1]+out[2], 1e-6)
+
+	// The activations are part of both serialized formats
+	encoded, err := nn.MarshalBinary()
+	assert.NoError(t, err)
+	decoded := new(Network)
+	assert.NoError(t, decoded.UnmarshalBinary(encoded))
+	assert.Equal(t, arch, decoded.Architecture())
+
+	encoded, err = json.Marshal(nn)
+	assert.NoError(t, err)
+	assert.Contains(t, string(encoded), `"activation":"softmax"`)
+	decoded = new(Network)
+	assert.NoError(t, json.Unmarshal(encoded, decoded))
+	assert.Equal(t, arch, decoded.Architecture())
+
+	assert.Panics(t, func() {
+		NewNetworkFrom(Architecture{Inputs: 1, Layers: []Spec{{Type: FFN, Size: 1, Activation: 100}}})
+	})
+}
+
+func TestArchitectureStateless(t *testing.T) {
+	nn := NewNetworkFrom(Architecture{
+		Inputs: 2,
+		Layers: []Spec{{Type: FFN, Size: 4}, {Type: FFN, Size: 1}},
+	})
+
+	in := []float32{0.5, 1}
+	assert.Equal(t, nn.Predict(in, nil), nn.Predict(in, nil))
+}
+
+func TestArchitectureDefault(t *testing.T) {
+	nn := NewNetwork([]int{2, 3, 1})
+	assert.Equal(t, Architecture{
+		Inputs: 2,
+		Layers: []Spec{{Type: MGU, Size: 3}, {Type: MGU, Size: 1}},
+	}, nn.Architecture())
+}
+
+func TestArchitectureInvalid(t *testing.T) {
+	for _, arch := range []Architecture{
+		{Inputs: 0, Layers: []Spec{{Type: FFN, Size: 1}}},
+		{Inputs: 1},
+		{Inputs: 1, Layers: []Spec{{Type: FFN, Size: 0}}},
+		{Inputs: 1, Layers: []Spec{{Type: "conv", Size: 1}}},
+	} {
+		assert.Panics(t, func() {
+			NewNetworkFrom(arch)
+		})
+	}
+}
+
+func TestArchitectureDense(t *testing.T) {
+	arch := Architecture{
+		Inputs: 2,
+		Layers: []Spec{
+			{Type: RNN, Size: 4, Dense: true},
+			{Type: MGU, Size: 4, Dense: true},
+			{Type: MGU, Size: 2},
+		},
+	}
+
+	nn := NewNetworkFrom(arch)
+	assert.Equal(t, arch, nn.Architecture())
+	assert.Len(t, nn.layers[0].weights[1].Data, 16)
+	assert.Len(t, nn.layers[2].weights[1].Data, 2)
+
+	// The dense matrices are part of both serialized formats
+	encoded, err := nn.MarshalBinary()
+	assert.NoError(t, err)
+	decoded := new(Network)
+	assert.NoError(t, decoded.UnmarshalBinary(encoded))
+	assert.Equal(t, arch, decoded.Architecture())
+	assert.Equal(t, nn.Predict([]float32{1, 2}, nil), decoded.Predict([]float32{1, 2}, nil))
+
+	encoded, err = json.Marshal(nn)
+	assert.NoError(t, err)
+	decoded = new(Network)
+	assert.NoError(t, json.Unmarshal(encoded, decoded))
+	assert.Equal(t, arch, decoded.Architecture())
+
+	assert.Panics(t, func() {
+		NewNetworkFrom(Architecture{Inputs: 1, Layers: []Spec{{Type: FFN, Size: 1, Dense: true}}})
+	})
+}
@@ -0,0 +1,303 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package neural
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/kelindar/evolve/neural/layer"
+	"github.com/kelindar/evolve/neural/math32"
+)
+
+// version represents the version of the serialization format, where the version 2 adds the
+// activation of every layer and the version 3 adds the dense recurrent matrices
+const version = 3
+
+// magic represents the header of the binary serialization format
+var magic = []byte("EVNN")
+
+// snapshot represents the serialized form of a network, shared by both formats
+type snapshot struct {
+	Version int             `json:"version"`
+	Shape   []int           `json:"shape"`
+	Layers  []layerSnapshot `json:"layers"`
+}
+
+// layerSnapshot represents the serialized form of a layer
+type layerSnapshot struct {
+	Type       Type             `json:"type"`
+	Activation layer.Activation `json:"activation,omitempty"`
+	Slope      float32          `json:"slope,omitempty"`
+	Dense      bool             `json:"dense,omitempty"`
+	Weights    []math32.Matrix  `json:"weights"`
+}
+
+// MarshalJSON encodes the network, along with its shape and the weights of every layer, as JSON
+func (nn *Network) MarshalJSON() ([]byte, error) {
+	nn.mu.Lock()
+	defer nn.mu.Unlock()
+	return json.Marshal(nn.snapshot())
+}
+
+// UnmarshalJSON decodes the network from JSON, validating that the weights match the shape
+func (nn *Network) UnmarshalJSON(data []byte) error {
+	var s snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	return nn.restore(&s)
+}
+
+// MarshalBinary encodes the network in a compact binary format, which starts with a header
+// and a version, followed by the shape and the weights of every layer.
+func (nn *Network) MarshalBinary() ([]byte, error) {
+	nn.mu.Lock()
+	defer nn.mu.Unlock()
+
+	s := nn.snapshot()
+	out := append([]byte(nil), magic...)
+	out = binary.AppendUvarint(out, uint64(s.Version))
+	out = binary.AppendUvarint(out, uint64(len(s.Shape)))
+	for _, size := range s.Shape {
+		out = binary.AppendUvarint(out, uint64(size))
+	}
+
+	for _, l := range s.Layers {
+		out = binary.AppendUvarint(out, uint64(len(l.Type)))
+		out = append(out, l.Type...)
+		out = binary.AppendUvarint(out, uint64(l.Activation))
+		out = binary.LittleEndian.AppendUint32(out, math.Float32bits(l.Slope))
+		out = binary.AppendUvarint(out, uint64(boolOf(l.Dense)))
+		out = binary.AppendUvarint(out, uint64(len(l.Weights)))
+		for _, mx := range l.Weights {
+			out = binary.AppendUvarint(out, uint64(mx.Rows))
+			out = binary.AppendUvarint(out, uint64(mx.Cols))
+			for _, v := range mx.Data {
+				out = binary.LittleEndian.AppendUint32(out, math.Float32bits(v))
+			}
+		}
+	}
+	return out, nil
+}
+
+// UnmarshalBinary decodes the network from the binary format, validating that the weights
+// match the shape
+func (nn *Network) UnmarshalBinary(data []byte) error {
+	if !bytes.HasPrefix(data, magic) {
+		return fmt.Errorf("neural: invalid binary header")
+	}
+
+	var s snapshot
+	r := &reader{buffer: data[len(magic):]}
+	s.Version = r.int()
+
+	s.Shape = make([]int, r.count(1))
+	for i := range s.Shape {
+		s.Shape[i] = r.int()
+	}
+
+	if len(s.Shape) < 2 {
+		return fmt.Errorf("neural: shape requires at least 2 layers, got %v", s.Shape)
+	}
+
+	s.Layers = make([]layerSnapshot, len(s.Shape)-1)
+	for i := range s.Layers {
+		s.Layers[i].Type = Type(r.bytes(r.count(1)))
+		if s.Version >= 2 {
+			s.Layers[i].Activation = layer.Activation(r.int())
+			s.Layers[i].Slope = r.float()
+		}
+		if s.Version >= 3 {
+			s.Layers[i].Dense = r.int() != 0
+		}
+
+		s.Layers[i].Weights = make([]math32.Matrix, r.count(2))
+		for j := range s.Layers[i].Weights {
+			mx := &s.Layers[i].Weights[j]
+			mx.Rows, mx.Cols = r.int(), r.int()
+			mx.Data = r.floats(mx.Rows * mx.Cols)
+		}
+	}
+
+	switch {
+	case r.err != nil:
+		return fmt.Errorf("neural: unable to decode, %w", r.err)
+	case len(r.buffer) > 0:
+		return fmt.Errorf("neural: unexpected %d trailing bytes", len(r.buffer))
+	}
+
+	return nn.restore(&s)
+}
+
+// snapshot returns the serialized form of the network, which shares its weights
+func (nn *Network) snapshot() *snapshot {
+	s := &snapshot{
+		Version: version,
+		Shape:   nn.shape,
+		Layers:  make([]layerSnapshot, 0, len(nn.layers)),
+	}
+
+	for _, l := range nn.layers {
+		out := layerSnapshot{
+			Type:       l.spec.Type,
+			Activation: l.spec.Activation,
+			Slope:      l.spec.Slope,
+			Dense:      l.spec.Dense,
+			Weights:    make([]math32.Matrix, 0, len(l.weights)),
+		}
+
+		for _, mx := range l.weights {
+			out.Weights = append(out.Weights, *mx)
+		}
+		s.Layers = append(s.Layers, out)
+	}
+	return s
+}
+
+// restore replaces the network with the serialized one, after validating it
+func (nn *Network) restore(s *snapshot) error {
+	switch {
+	case s.Version < 1 || s.Version > version:
+		return fmt.Errorf("neural: unsupported version %d", s.Version)
+	case len(s.Shape) < 2:
+		return fmt.Errorf("neural: shape requires at least 2 layers, got %v", s.Shape)
+	case len(s.Layers) != len(s.Shape)-1:
+		return fmt.Errorf("neural: shape %v requires %d layers, got %d", s.Shape, len(s.Shape)-1, len(s.Layers))
+	}
+
+	// Build the layers from the architecture, then load their weights
+	arch := Architecture{Inputs: s.Shape[0]}
+	for i, l := range s.Layers {
+		arch.Layers = append(arch.Layers, Spec{
+			Type:       l.Type,
+			Size:       s.Shape[i+1],
+			Activation: l.Activation,
+			Slope:      l.Slope,
+			Dense:      l.Dense,
+		})
+	}
+
+	layers, err := arch.build()
+	if err != nil {
+		return err
+	}
+
+	for i, l := range s.Layers {
+		if err := copyWeights(layers[i].weights, l.Weights); err != nil {
+			return fmt.Errorf("neural: layer %d (%s), %w", i, l.Type, err)
+		}
+	}
+
+	nn.mu.Lock()
+	defer nn.mu.Unlock()
+	nn.shape = append([]int(nil), s.Shape...)
+	nn.sensorSize = s.Shape[0]
+	nn.outputSize = s.Shape[len(s.Shape)-1]
+	nn.layers = layers
+	nn.state = nn.NewState()
+	nn.training = nil // The buffers point to the weights of the replaced layers
+	return nil
+}
+
+// copyWeights copies the weights into the matrices of a layer, validating their shapes
+func copyWeights(dst []*math32.Matrix, src []math32.Matrix) error {
+	if len(dst) != len(src) {
+		return fmt.Errorf("expected %d matrices, got %d", len(dst), len(src))
+	}
+
+	for i, mx := range src {
+		if mx.Rows != dst[i].Rows || mx.Cols != dst[i].Cols || len(mx.Data) != mx.Rows*mx.Cols {
+			return fmt.Errorf("expected matrix %d of %dx%d, got %dx%d with %d values",
+				i, dst[i].Rows, dst[i].Cols, mx.Rows, mx.Cols, len(mx.Data))
+		}
+
+		copy(dst[i].Data, mx.Data)
+	}
+	return nil
+}
+
+// boolOf returns 1 if the value is true, or 0 otherwise
+func boolOf(v bool) int {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// ---------------------------------- Reader ----------------------------------
+
+// reader reads the binary format, remembering the first error encountered
+type reader struct {
+	buffer []byte
+	err    error
+}
+
+// int reads a variable-length integer
+func (r *reader) int() int {
+	if r.err != nil {
+		return 0
+	}
+
+	v, n := binary.Uvarint(r.buffer)
+	if n <= 0 || v > math.MaxInt32 {
+		r.err = io.ErrUnexpectedEOF
+		return 0
+	}
+
+	r.buffer = r.buffer[n:]
+	return int(v)
+}
+
+// count reads the number of elements, where every element takes at least the specified
+// number of bytes, which avoids allocating for a corrupted count
+func (r *reader) count(size int) int {
+	n := r.int()
+	if size > 0 && n > len(r.buffer)/size+1 {
+		r.err = io.ErrUnexpectedEOF
+		return 0
+	}
+	return n
+}
+
+// bytes reads the specified number of bytes
+func (r *reader) bytes(n int) []byte {
+	if r.err != nil || n > len(r.buffer) {
+		r.err = io.ErrUnexpectedEOF
+		return nil
+	}
+
+	out := r.buffer[:n]
+	r.buffer = r.buffer[n:]
+	return out
+}
+
+// float reads a little-endian float32 value
+func (r *reader) float() float32 {
+	if b := r.bytes(4); b != nil {
+		return math.Float32frombits(binary.LittleEndian.Uint32(b))
+	}
+	return 0
+}
+
+// floats reads the specified number of little-endian float32 values
+func (r *reader) floats(n int) []float32 {
+	if r.err != nil || n > len(r.buffer)/4 {
+		r.err = io.ErrUnexpectedEOF
+		return nil
+	}
+
+	out := make([]float32, n)
+	for i := range out {
+		out[i] = math.Float32frombits(binary.LittleEndian.Uint32(r.buffer[i*4:]))
+	}
+
+	r.buffer = r.buffer[n*4:]
+	return out
+}
@@ -0,0 +1,99 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package neural
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kelindar/evolve/neural/layer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodecJSON(t *testing.T) {
+	nn := NewNetwork([]int{3, 4, 2})
+	nn.layers[1] = bind(layer.NewRNN(4, 2), Spec{Type: RNN, Size: 2})
+
+	out, err := json.Marshal(nn)
+	assert.NoError(t, err)
+
+	decoded := new(Network)
+	assert.NoError(t, json.Unmarshal(out, decoded))
+	assertSame(t, nn, decoded)
+	assert.Contains(t, nn.String(), `"type": "rnn"`)
+}
+
+func TestCodecBinary(t *testing.T) {
+	nn := NewNetwork([]int{3, 4, 4, 2})
+	nn.layers[0] = bind(layer.NewFFN(3, 4), Spec{Type: FFN, Size: 4})
+
+	out, err := nn.MarshalBinary()
+	assert.NoError(t, err)
+
+	decoded := new(Network)
+	assert.NoError(t, decoded.UnmarshalBinary(out))
+	assertSame(t, nn, decoded)
+
+	// Every truncation must be detected
+	for i := 0; i < len(out); i++ {
+		assert.Error(t, new(Network).UnmarshalBinary(out[:i]))
+	}
+
+	assert.Error(t, new(Network).UnmarshalBinary(append(out, 0)))
+}
+
+func TestCodecInvalid(t *testing.T) {
+	for _, tc := range []string{
+		`{"version":4,"shape":[1,1],"layers":[{"type":"ffn","weights":[{"rows":1,"cols":1,"data":[1]}]}]}`,
+		`{"version":1,"shape":[1],"layers":[]}`,
+		`{"version":1,"shape":[1,1],"layers":[]}`,
+		`{"version":1,"shape":[1,0],"layers":[{"type":"ffn","weights":[]}]}`,
+		`{"version":1,"shape":[1,1],"layers":[{"type":"conv","weights":[]}]}`,
+		`{"version":2,"shape":[1,1],"layers":[{"type":"ffn","activation":"cubic","weights":[]}]}`,
+		`{"version":1,"shape":[1,1],"layers":[{"type":"ffn","weights":[]}]}`,
+		`{"version":1,"shape":[1,1],"layers":[{"type":"ffn","weights":[{"rows":1,"cols":2,"data":[1,2]}]}]}`,
+		`{"version":1,"shape":[1,1],"layers":[{"type":"ffn","weights":[{"rows":1,"cols":1,"data":[1,2]}]}]}`,
+	} {
+		assert.Error(t, json.Unmarshal([]byte(tc), new(Network)), tc)
+	}
+
+	assert.NoError(t, json.Unmarshal([]byte(
+		`{"version":1,"shape":[1,1],"layers":[{"type":"ffn","weights":[{"rows":1,"cols":1,"data":[1]}]}]}`,
+	), new(Network)))
+	assert.Error(t, new(Network).UnmarshalBinary([]byte("JSON")))
+}
+
+func TestNewWithWeights(t *testing.T) {
+	nn := NewNetwork([]int{1, 1}, []float32{1}, []float32{2}, []float32{3}, []float32{4}, []float32{5}, []float32{6})
+	assert.Equal(t, []float32{1}, nn.layers[0].Layer.(*layer.MGU).Wf.Data)
+	assert.Equal(t, []float32{6}, nn.layers[0].Layer.(*layer.MGU).Bh.Data)
+
+	assert.Panics(t, func() {
+		NewNetwork([]int{1, 1}, []float32{1})
+	})
+	assert.Panics(t, func() {
+		NewNetwork([]int{1, 1}, []float32{1}, []float32{2}, []float32{3}, []float32{4}, []float32{5}, []float32{6, 7})
+	})
+
+	// The weights can be replaced in place, which clears the recurrent state
+	first := nn.Predict([]float32{1}, nil)
+	nn.SetWeights([]float32{6}, []float32{5}, []float32{4}, []float32{3}, []float32{2}, []float32{1})
+	assert.Equal(t, []float32{6}, nn.layers[0].Layer.(*layer.MGU).Wf.Data)
+	assert.NotEqual(t, first, nn.Predict([]float32{1}, nil))
+	assert.Panics(t, func() {
+		nn.SetWeights([]float32{1})
+	})
+}
+
+// assertSame asserts that both networks have the same weights and predictions
+func assertSame(t *testing.T, expect, actual *Network) {
+	assert.Equal(t, expect.shape, actual.shape)
+	assert.Equal(t, 0.0, expect.Distance(actual))
+
+	in := make([]float32, expect.sensorSize)
+	for i := range in {
+		in[i] = float32(i) + 0.5
+	}
+	assert.Equal(t, expect.Predict(in, nil), actual.Predict(in, nil))
+}
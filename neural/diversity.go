@@ -0,0 +1,68 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package neural
+
+import (
+	"math"
+)
+
+// Distance returns the euclidean distance between the weights of the two networks, which
+// are expected to share the same architecture.
+func (nn *Network) Distance(other *Network) float64 {
+	var sum float64
+	nn.rangeWeights(other, func(w1, w2 float32) {
+		d := float64(w1 - w2)
+		sum += d * d
+	})
+	return math.Sqrt(sum)
+}
+
+// Spread computes the mean standard deviation of every weight across the networks, which
+// are expected to share the same architecture. A spread close to zero means that the
+// networks have converged. A random sample of the population is usually enough.
+func Spread(networks ...*Network) float64 {
+	if len(networks) == 0 {
+		return 0
+	}
+
+	// Accumulate the moments of each weight
+	var sum, squares []float64
+	for _, nn := range networks {
+		i := 0
+		nn.rangeWeights(nn, func(w, _ float32) {
+			if i == len(sum) {
+				sum = append(sum, 0)
+				squares = append(squares, 0)
+			}
+
+			sum[i] += float64(w)
+			squares[i] += float64(w) * float64(w)
+			i++
+		})
+	}
+
+	if len(sum) == 0 {
+		return 0
+	}
+
+	var spread float64
+	n := float64(len(networks))
+	for i := range sum {
+		mean := sum[i] / n
+		spread += math.Sqrt(math.Max(squares[i]/n-mean*mean, 0))
+	}
+	return spread / float64(len(sum))
+}
+
+// rangeWeights iterates over the matching weights of the two networks
+func (nn *Network) rangeWeights(other *Network, fn func(w1, w2 float32)) {
+	for i := range nn.layers {
+		m1, m2 := nn.layers[i].weights, other.layers[i].weights
+		for j := range m1 {
+			for k, w := range m1[j].Data {
+				fn(w, m2[j].Data[k])
+			}
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package neural
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistance(t *testing.T) {
+	nn := NewNetwork([]int{2, 4, 1})
+	clone := nn.Clone()
+	assert.Equal(t, 0.0, nn.Distance(clone))
+
+	// Shift a single weight of the clone
+	clone.layers[0].weights[0].Data[0] += 2
+	assert.InDelta(t, 2.0, nn.Distance(clone), 1e-6)
+}
+
+func TestSpread(t *testing.T) {
+	nn := NewNetwork([]int{2, 4, 1})
+	assert.Equal(t, 0.0, Spread(nn, nn.Clone()))
+	assert.Equal(t, 0.0, Spread())
+
+	// A single weight out of all of them differs by 2, hence its deviation is 1
+	count := 0
+	nn.rangeWeights(nn, func(_, _ float32) { count++ })
+
+	clone := nn.Clone()
+	clone.layers[0].weights[0].Data[0] += 2
+	assert.InDelta(t, 1/float64(count), Spread(nn, clone), 1e-6)
+}
@@ -30,6 +30,12 @@ func main() {
 		return neural.NewNetwork([]int{4, 8, 8, 8, 4})
 	})
 
+	// Replace the worst half of the population with random networks when stuck
+	pop.SetRestart(evolve.Restart{
+		Strategy:   evolve.PartialRestart,
+		Stagnation: epoch / 2,
+	})
+
 	var solved float64
 	for i := 1; ; i++ { // loop forever
 		fittest := pop.Evolve()
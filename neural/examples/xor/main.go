@@ -9,6 +9,7 @@ import (
 
 	"github.com/kelindar/evolve"
 	"github.com/kelindar/evolve/neural"
+	"github.com/kelindar/evolve/neural/math32"
 )
 
 // XOR tests
@@ -27,6 +28,16 @@ func main() {
 		return neural.NewNetwork([]int{2, 2, 1})
 	})
 
+	// Fine-tune every network with a few steps of gradient descent before it is evaluated,
+	// and let the offspring inherit the learned weights
+	inputs, targets := samples()
+	pop.SetLocalSearch(evolve.Lamarckian, func(nn *neural.Network) {
+		optimizer := &neural.Adam{Rate: 0.05}
+		for i := 0; i < 5; i++ {
+			nn.TrainSequence(optimizer, inputs, targets)
+		}
+	})
+
 	for i := 0; ; i++ { // loop forever
 		fittest := pop.Evolve()
 		fitness := evaluateXOR(fittest) / float32(len(tests)) * 100
@@ -40,6 +51,16 @@ func main() {
 	}
 }
 
+// samples returns the tests as a sequence of supervised samples, since the fitness function
+// predicts them one after another
+func samples() (inputs, targets []math32.Matrix) {
+	for _, tc := range tests {
+		inputs = append(inputs, math32.NewMatrix(1, 2, tc.input))
+		targets = append(targets, math32.NewMatrix(1, 1, []float32{tc.output}))
+	}
+	return
+}
+
 func evaluateXOR(g *neural.Network) (score float32) {
 	for _, tc := range tests {
 		out := g.Predict(tc.input, nil)[0]
@@ -0,0 +1,247 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package neural
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/kelindar/evolve/neural/layer"
+	"github.com/kelindar/evolve/neural/math32"
+)
+
+// trainable represents a layer which supports the back-propagation of the gradients
+type trainable interface {
+	Record(x *math32.Matrix, tape *layer.Tape) *math32.Matrix
+	Backward(tape *layer.Tape, dy []math32.Matrix, grads []*math32.Matrix) []math32.Matrix
+}
+
+// training represents the buffers needed to train a network, allocated on first use
+type training struct {
+	layers    []trainable        // The layers of the network
+	tapes     []layer.Tape       // The recorded forward pass of every layer
+	weights   []*math32.Matrix   // The weights of every layer, in the serialization order
+	gradients []*math32.Matrix   // The gradients of the weights, in the same order
+	grads     [][]*math32.Matrix // The gradients of the weights of every layer
+	dy        []math32.Matrix    // The gradients of the outputs of every step
+}
+
+// Train performs a single step of gradient descent on a batch of independent samples, one
+// per row of the input and target matrices, which minimizes the mean squared error between
+// the outputs and the targets. It returns the loss before the step. The recurrent layers
+// start from a zero state, and the state used by Predict is not modified.
+func (nn *Network) Train(optimizer Optimizer, input, target *math32.Matrix) float32 {
+	return nn.TrainSequence(optimizer, []math32.Matrix{*input}, []math32.Matrix{*target})
+}
+
+// TrainSequence performs a single step of gradient descent on a batch of sequences, where
+// every matrix is a step of the sequences with one row per sequence, and the gradients are
+// back-propagated through time. Only the feed-forward, RNN and MGU layers support gradients,
+// and it panics if the network contains any other layer. It returns the loss before the step.
+func (nn *Network) TrainSequence(optimizer Optimizer, inputs, targets []math32.Matrix) float32 {
+	if len(inputs) != len(targets) {
+		panic(fmt.Errorf("neural: expected targets for %d steps, got %d", len(inputs), len(targets)))
+	}
+
+	nn.mu.Lock()
+	defer nn.mu.Unlock()
+	t := nn.trainer()
+	for i := range t.tapes {
+		t.tapes[i].Reset()
+	}
+
+	// Forward pass, computing the error of every step
+	for len(t.dy) < len(inputs) {
+		t.dy = append(t.dy, math32.Matrix{})
+	}
+
+	loss, count := float64(0), 0
+	for i := range inputs {
+		input, target := &inputs[i], &targets[i]
+		switch {
+		case input.Cols != nn.sensorSize:
+			panic(fmt.Errorf("neural: expected %d inputs per row, got %d", nn.sensorSize, input.Cols))
+		case target.Cols != nn.outputSize || target.Rows != input.Rows:
+			panic(fmt.Errorf("neural: expected %dx%d targets, got %dx%d", input.Rows, nn.outputSize, target.Rows, target.Cols))
+		}
+
+		x := input
+		for j, l := range t.layers {
+			x = l.Record(x, &t.tapes[j])
+		}
+
+		dy := &t.dy[i]
+		dy.Reset(x.Rows, x.Cols)
+		for j, v := range x.Data {
+			diff := v - target.Data[j]
+			dy.Data[j] = diff
+			loss += float64(diff * diff)
+		}
+		count += len(x.Data)
+	}
+
+	if count == 0 {
+		return 0
+	}
+
+	// Backward pass of the gradient of the mean squared error, from the last layer to the first
+	dy := t.dy[:len(inputs)]
+	for _, m := range dy {
+		for j := range m.Data {
+			m.Data[j] *= 2 / float32(count)
+		}
+	}
+
+	for _, g := range t.gradients {
+		g.Zero()
+	}
+
+	for i := len(t.layers) - 1; i >= 0; i-- {
+		dy = t.layers[i].Backward(&t.tapes[i], dy, t.grads[i])
+	}
+
+	optimizer.Update(t.weights, t.gradients)
+	return float32(loss / float64(count))
+}
+
+// trainer returns the training buffers, or panics if a layer does not support gradients
+func (nn *Network) trainer() *training {
+	if nn.training != nil {
+		return nn.training
+	}
+
+	t := &training{
+		tapes: make([]layer.Tape, len(nn.layers)),
+	}
+
+	for _, l := range nn.layers {
+		tl, ok := l.Layer.(trainable)
+		if !ok {
+			panic(fmt.Errorf("neural: layer %T does not support gradients", l.Layer))
+		}
+
+		grads := make([]*math32.Matrix, 0, len(l.weights))
+		for _, w := range l.weights {
+			g := math32.NewMatrix(w.Rows, w.Cols, nil)
+			grads = append(grads, &g)
+		}
+
+		t.layers = append(t.layers, tl)
+		t.weights = append(t.weights, l.weights...)
+		t.gradients = append(t.gradients, grads...)
+		t.grads = append(t.grads, grads)
+	}
+
+	nn.training = t
+	return t
+}
+
+// ---------------------------------- Optimizers ----------------------------------
+
+// Optimizer represents a gradient-based optimizer, which updates the weights with the
+// gradients of the loss. Since an optimizer may keep a state for every weight, such as the
+// momentum, a separate optimizer should be used for every network.
+type Optimizer interface {
+	Update(weights, gradients []*math32.Matrix)
+}
+
+// SGD is the stochastic gradient descent optimizer, with an optional momentum
+type SGD struct {
+	Rate     float32     // The learning rate (default: 0.1)
+	Momentum float32     // The momentum, typically 0.9 (default: none)
+	velocity [][]float32 // The velocity of every weight
+}
+
+// Update updates the weights with the gradients
+func (o *SGD) Update(weights, gradients []*math32.Matrix) {
+	rate := defaultOf(o.Rate, 0.1)
+	if o.Momentum == 0 {
+		for i, w := range weights {
+			math32.Axpy(w.Data, gradients[i].Data, -rate)
+		}
+		return
+	}
+
+	o.velocity = moments(o.velocity, weights)
+	for i, w := range weights {
+		v := o.velocity[i]
+		for j, g := range gradients[i].Data {
+			v[j] = o.Momentum*v[j] - rate*g
+		}
+		math32.Add(w.Data, v)
+	}
+}
+
+// Adam is the adaptive moment estimation optimizer of https://arxiv.org/abs/1412.6980
+type Adam struct {
+	Rate    float32     // The learning rate (default: 0.001)
+	Beta1   float32     // The decay of the first moment (default: 0.9)
+	Beta2   float32     // The decay of the second moment (default: 0.999)
+	Epsilon float32     // The term added for numerical stability (default: 1e-8)
+	m, v    [][]float32 // The first and second moments of every weight
+	t       int         // The number of updates
+}
+
+// Update updates the weights with the gradients
+func (o *Adam) Update(weights, gradients []*math32.Matrix) {
+	rate := defaultOf(o.Rate, 0.001)
+	beta1 := defaultOf(o.Beta1, 0.9)
+	beta2 := defaultOf(o.Beta2, 0.999)
+	epsilon := defaultOf(o.Epsilon, 1e-8)
+
+	if !sameShape(o.m, weights) {
+		o.m = moments(o.m, weights)
+		o.v = moments(o.v, weights)
+		o.t = 0
+	}
+
+	o.t++
+
+	// Correct the bias of the moments, which are initialized at zero
+	c1 := 1 - float32(math.Pow(float64(beta1), float64(o.t)))
+	c2 := 1 - float32(math.Pow(float64(beta2), float64(o.t)))
+	for i, w := range weights {
+		m, v := o.m[i], o.v[i]
+		for j, g := range gradients[i].Data {
+			m[j] = beta1*m[j] + (1-beta1)*g
+			v[j] = beta2*v[j] + (1-beta2)*g*g
+			w.Data[j] -= rate * (m[j] / c1) / (float32(math.Sqrt(float64(v[j]/c2))) + epsilon)
+		}
+	}
+}
+
+// moments returns the per-weight state of an optimizer, allocating it if the weights change
+func moments(state [][]float32, weights []*math32.Matrix) [][]float32 {
+	if sameShape(state, weights) {
+		return state
+	}
+
+	state = make([][]float32, len(weights))
+	for i, w := range weights {
+		state[i] = make([]float32, len(w.Data))
+	}
+	return state
+}
+
+// sameShape returns whether the state has one slice of the same length per weight matrix
+func sameShape(state [][]float32, weights []*math32.Matrix) bool {
+	if len(state) != len(weights) {
+		return false
+	}
+
+	for i, w := range weights {
+		if len(state[i]) != len(w.Data) {
+			return false
+		}
+	}
+	return true
+}
+
+// defaultOf returns the value, or the default value if it is zero
+func defaultOf(value, defaultValue float32) float32 {
+	if value == 0 {
+		return defaultValue
+	}
+	return value
+}
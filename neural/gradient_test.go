@@ -0,0 +1,231 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package neural
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kelindar/evolve"
+	"github.com/kelindar/evolve/neural/layer"
+	"github.com/kelindar/evolve/neural/math32"
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+cpu: Intel(R) Xeon(R) Processor
+BenchmarkTrain   	    2162	   1369469 ns/op	     306 B/op	       1 allocs/op
+*/
+func BenchmarkTrain(b *testing.B) {
+	const batch = 64
+	nn := NewNetworkFrom(Architecture{
+		Inputs: 10,
+		Layers: []Spec{
+			{Type: FFN, Size: 128},
+			{Type: FFN, Size: 128},
+			{Type: FFN, Size: 4, Activation: layer.Identity},
+		},
+	})
+
+	input := math32.NewMatrix(batch, 10, nil)
+	target := math32.NewMatrix(batch, 4, nil)
+	optimizer := &Adam{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		nn.Train(optimizer, &input, &target)
+	}
+}
+
+func TestGradient(t *testing.T) {
+	nn := NewNetworkFrom(Architecture{
+		Inputs: 3,
+		Layers: []Spec{
+			{Type: FFN, Size: 5, Activation: layer.Swish},
+			{Type: RNN, Size: 4, Activation: layer.Tanh},
+			{Type: RNN, Size: 4, Activation: layer.GELU, Dense: true},
+			{Type: MGU, Size: 4},
+			{Type: MGU, Size: 3, Activation: layer.Softplus, Dense: true},
+			{Type: FFN, Size: 2, Activation: layer.Softmax},
+		},
+	})
+
+	// A sequence of 3 steps, with 2 independent sequences per step
+	inputs := make([]math32.Matrix, 3)
+	targets := make([]math32.Matrix, 3)
+	for i := range inputs {
+		inputs[i] = math32.NewMatrix(2, 3, []float32{0.5, -1, float32(i), 1, 0.25, -0.5})
+		targets[i] = math32.NewMatrix(2, 2, []float32{1, 0, 0, 1})
+	}
+
+	// The analytical gradients must match the numerical ones
+	g := new(capture)
+	nn.TrainSequence(g, inputs, targets)
+	for i, w := range g.weights {
+		for j := range w.Data {
+			const eps = 1e-2
+			v := w.Data[j]
+			w.Data[j] = v + eps
+			loss1 := nn.TrainSequence(new(capture), inputs, targets)
+			w.Data[j] = v - eps
+			loss2 := nn.TrainSequence(new(capture), inputs, targets)
+			w.Data[j] = v
+
+			assert.InDelta(t, (loss1-loss2)/(2*eps), g.gradients[i][j], 1e-3, "matrix %d, weight %d", i, j)
+		}
+	}
+}
+
+func TestTrain(t *testing.T) {
+	nn := NewNetworkFrom(Architecture{
+		Inputs: 2,
+		Layers: []Spec{
+			{Type: RNN, Size: 8, Activation: layer.Tanh},
+			{Type: FFN, Size: 1, Activation: layer.Sigmoid},
+		},
+	})
+
+	input := math32.NewMatrix(4, 2, []float32{0, 0, 0, 1, 1, 0, 1, 1})
+	target := math32.NewMatrix(4, 1, []float32{0, 1, 1, 0})
+	before := nn.Predict([]float32{0, 1}, nil)
+
+	// Fitting XOR only takes a few hundred steps of gradient descent
+	optimizer := &Adam{Rate: 0.05}
+	first := nn.Train(optimizer, &input, &target)
+	loss := first
+	for i := 0; i < 500; i++ {
+		loss = nn.Train(optimizer, &input, &target)
+	}
+
+	assert.Less(t, loss, first)
+	assert.Less(t, loss, float32(0.01))
+	assert.NotEqual(t, before, nn.Predict([]float32{0, 1}, nil))
+	for i := 0; i < 4; i++ {
+		nn.Reset()
+		out := nn.Predict(input.Data[i*2:(i+1)*2], nil)
+		assert.InDelta(t, target.Data[i], out[0], 0.2)
+	}
+}
+
+func TestTrainDecoded(t *testing.T) {
+	input := math32.NewMatrix(1, 2, []float32{1, 0})
+	target := math32.NewMatrix(1, 1, []float32{1})
+	for _, codec := range []struct {
+		encode func(*Network) ([]byte, error)
+		decode func(*Network, []byte) error
+	}{
+		{func(nn *Network) ([]byte, error) { return json.Marshal(nn) }, func(nn *Network, b []byte) error { return json.Unmarshal(b, nn) }},
+		{(*Network).MarshalBinary, (*Network).UnmarshalBinary},
+	} {
+		nn := NewNetwork([]int{2, 3, 1})
+		nn.Train(&SGD{}, &input, &target)
+
+		// Decoding into a trained network replaces its layers, which must be trained from then on
+		out, err := codec.encode(nn)
+		assert.NoError(t, err)
+		assert.NoError(t, codec.decode(nn, out))
+
+		before := nn.Predict(input.Data, nil)
+		nn.Train(&SGD{}, &input, &target)
+		nn.Reset()
+		assert.NotEqual(t, before, nn.Predict(input.Data, nil))
+	}
+}
+
+func TestTrainInvalid(t *testing.T) {
+	input := math32.NewMatrix(1, 2, nil)
+	target := math32.NewMatrix(1, 1, nil)
+
+	assert.Panics(t, func() {
+		NewNetworkFrom(Architecture{
+			Inputs: 2,
+			Layers: []Spec{{Type: LSTM, Size: 1}},
+		}).Train(&SGD{}, &input, &target)
+	})
+
+	nn := NewNetwork([]int{2, 3, 1})
+	assert.Panics(t, func() {
+		nn.Train(&SGD{}, &target, &target)
+	})
+
+	assert.Panics(t, func() {
+		nn.Train(&SGD{}, &input, &input)
+	})
+
+	assert.Panics(t, func() {
+		nn.TrainSequence(&SGD{}, []math32.Matrix{input}, nil)
+	})
+
+	assert.Equal(t, float32(0), nn.TrainSequence(&SGD{}, nil, nil))
+}
+
+func TestOptimizer(t *testing.T) {
+	w := math32.NewMatrix(1, 2, []float32{1, 1})
+	g := math32.NewMatrix(1, 2, []float32{0.5, -2})
+	weights, gradients := []*math32.Matrix{&w}, []*math32.Matrix{&g}
+
+	(&SGD{}).Update(weights, gradients)
+	assert.InDeltaSlice(t, []float32{0.95, 1.2}, w.Data, 1e-6)
+
+	w.Data[0], w.Data[1] = 1, 1
+	sgd := &SGD{Rate: 0.1, Momentum: 0.9}
+	sgd.Update(weights, gradients)
+	sgd.Update(weights, gradients)
+	assert.InDeltaSlice(t, []float32{0.855, 1.58}, w.Data, 1e-6)
+
+	// The first step of Adam is of the size of the learning rate
+	w.Data[0], w.Data[1] = 1, 1
+	(&Adam{}).Update(weights, gradients)
+	assert.InDeltaSlice(t, []float32{0.999, 1.001}, w.Data, 1e-6)
+}
+
+func TestLocalSearch(t *testing.T) {
+	pop := evolve.New(64, evaluateXOR, func() *Network {
+		return NewNetwork([]int{2, 4, 1})
+	})
+
+	// The fitness function predicts the cases one after another, as a sequence
+	inputs := []math32.Matrix{
+		math32.NewMatrix(1, 2, []float32{0, 0}),
+		math32.NewMatrix(1, 2, []float32{0, 1}),
+		math32.NewMatrix(1, 2, []float32{1, 0}),
+		math32.NewMatrix(1, 2, []float32{1, 1}),
+	}
+	targets := []math32.Matrix{
+		math32.NewMatrix(1, 1, []float32{0}),
+		math32.NewMatrix(1, 1, []float32{1}),
+		math32.NewMatrix(1, 1, []float32{1}),
+		math32.NewMatrix(1, 1, []float32{0}),
+	}
+
+	// A few steps of gradient descent on every genome before it is evaluated
+	pop.SetLocalSearch(evolve.Lamarckian, func(nn *Network) {
+		optimizer := &Adam{Rate: 0.05}
+		for i := 0; i < 10; i++ {
+			nn.TrainSequence(optimizer, inputs, targets)
+		}
+	})
+
+	var fittest *Network
+	for i := 0; i < 20; i++ {
+		fittest = pop.Evolve()
+	}
+
+	fittest.Reset()
+	assert.InDelta(t, 1, evaluateXOR(fittest)/4, 0.05)
+}
+
+// capture captures the gradients without updating the weights
+type capture struct {
+	weights   []*math32.Matrix
+	gradients [][]float32
+}
+
+func (c *capture) Update(weights, gradients []*math32.Matrix) {
+	c.weights = weights
+	for _, g := range gradients {
+		c.gradients = append(c.gradients, append([]float32(nil), g.Data...))
+	}
+}
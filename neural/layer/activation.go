@@ -0,0 +1,96 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package layer
+
+import (
+	"fmt"
+
+	"github.com/kelindar/evolve/neural/math32"
+)
+
+// Activation represents an activation function applied to the outputs of a layer
+type Activation uint8
+
+// Supported activation functions
+const (
+	Default   Activation = iota // The default activation of the layer
+	Identity                    // f(x) = x, typically for regression outputs
+	ReLU                        // f(x) = max(x, 0)
+	LeakyReLU                   // f(x) = x if x > 0, otherwise slope·x
+	Tanh                        // f(x) = tanh(x)
+	Sigmoid                     // f(x) = 1 / (1 + e^-x)
+	Swish                       // f(x) = x·sigmoid(x)
+	Softplus                    // f(x) = ln(1 + e^x)
+	GELU                        // f(x) = x·Φ(x), using the tanh approximation
+	Softmax                     // normalizes every row into a probability distribution
+)
+
+// names contains the names of the activation functions
+var names = [...]string{"default", "identity", "relu", "lrelu", "tanh", "sigmoid", "swish", "softplus", "gelu", "softmax"}
+
+// String returns the name of the activation function
+func (a Activation) String() string {
+	if int(a) < len(names) {
+		return names[a]
+	}
+	return "unknown"
+}
+
+// MarshalText encodes the activation function as its name
+func (a Activation) MarshalText() ([]byte, error) {
+	if int(a) >= len(names) {
+		return nil, fmt.Errorf("layer: unknown activation %d", a)
+	}
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText decodes the activation function from its name
+func (a *Activation) UnmarshalText(text []byte) error {
+	for i, name := range names {
+		if name == string(text) {
+			*a = Activation(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("layer: unknown activation %q", text)
+}
+
+// or returns the activation, or the fallback if it is the default one
+func (a Activation) or(fallback Activation) Activation {
+	if a == Default {
+		return fallback
+	}
+	return a
+}
+
+// activate applies the activation function to every row of the matrix in place. The slope
+// of the leaky ReLU defaults to 0.01 if not specified.
+func activate(m *math32.Matrix, fn Activation, slope float32) {
+	switch fn {
+	case Identity:
+	case ReLU:
+		math32.Relu(m.Data)
+	case LeakyReLU:
+		if slope == 0 {
+			slope = 0.01
+		}
+		math32.LeakyRelu(m.Data, slope)
+	case Tanh:
+		math32.Tanh(m.Data)
+	case Sigmoid:
+		math32.Sigmoid(m.Data)
+	case Swish:
+		math32.Swish(m.Data)
+	case Softplus:
+		math32.Softplus(m.Data)
+	case GELU:
+		math32.Gelu(m.Data)
+	case Softmax:
+		for i := 0; i < m.Rows; i++ {
+			math32.Softmax(m.Data[i*m.Cols : (i+1)*m.Cols])
+		}
+	default:
+		panic(fmt.Errorf("layer: unknown activation %d", fn))
+	}
+}
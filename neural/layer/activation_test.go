@@ -0,0 +1,64 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package layer
+
+import (
+	"testing"
+
+	"github.com/kelindar/evolve/neural/math32"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActivation(t *testing.T) {
+	l := NewFFN(2, 3)
+	l.Wx = math32.NewMatrix(2, 3, []float32{
+		1, -2, 3,
+		-4, 5, -6,
+	})
+
+	x := math32.NewMatrix(1, 2, []float32{1, 2})
+	var dst math32.Matrix
+
+	l.Activation = Identity
+	assert.Equal(t, []float32{-7, 8, -9}, l.Forward(&dst, &x, nil).Data)
+
+	l.Activation = ReLU
+	assert.Equal(t, []float32{0, 8, 0}, l.Forward(&dst, &x, nil).Data)
+
+	l.Activation, l.Slope = LeakyReLU, 0.5
+	assert.Equal(t, []float32{-3.5, 8, -4.5}, l.Forward(&dst, &x, nil).Data)
+
+	l.Activation = Default
+	assert.Equal(t, []float32{-3.5, 8, -4.5}, l.Clone().Forward(&dst, &x, nil).Data)
+
+	l.Activation = Softmax
+	out := l.Forward(&dst, &x, nil).Data
+	assert.InDelta(t, 1, out[0]+out[1]+out[2], 1e-6)
+	assert.Greater(t, out[1], out[0])
+}
+
+func TestActivationSoftmaxRows(t *testing.T) {
+	m := math32.NewMatrix(2, 2, []float32{1, 1, 0, 100})
+	activate(&m, Softmax, 0)
+	assert.InDeltaSlice(t, []float32{0.5, 0.5, 0, 1}, m.Data, 1e-6)
+}
+
+func TestActivationText(t *testing.T) {
+	for a := Default; a <= Softmax; a++ {
+		text, err := a.MarshalText()
+		assert.NoError(t, err)
+
+		var decoded Activation
+		assert.NoError(t, decoded.UnmarshalText(text))
+		assert.Equal(t, a, decoded)
+	}
+
+	var decoded Activation
+	assert.Error(t, decoded.UnmarshalText([]byte("cubic")))
+	_, err := Activation(100).MarshalText()
+	assert.Error(t, err)
+	assert.Panics(t, func() {
+		activate(&math32.Matrix{}, Activation(100), 0)
+	})
+}
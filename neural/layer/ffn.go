@@ -6,7 +6,6 @@ package layer
 import (
 	"math/rand"
 
-	"github.com/kelindar/evolve"
 	"github.com/kelindar/evolve/neural/math32"
 )
 
@@ -14,6 +13,8 @@ type FFN struct {
 	inputSize  int
 	hiddenSize int
 	Wx         math32.Matrix // input weights
+	Activation Activation    // activation of the outputs (default: leaky ReLU)
+	Slope      float32       // slope of the leaky ReLU (default: 0.01)
 }
 
 // NewFFN creates a new feed-forward network layer
@@ -25,21 +26,51 @@ func NewFFN(inputSize, hiddenSize int) *FFN {
 	}
 }
 
-func (l *FFN) Update(dst, x *math32.Matrix) *math32.Matrix {
+// Forward computes the outputs of the layer, which has no recurrent state to update
+func (l *FFN) Forward(dst, x *math32.Matrix, _ *State) *math32.Matrix {
 	dst.Reset(x.Rows, l.Wx.Cols)
 	math32.Matmul(dst, x, &l.Wx)
-	math32.Lrelu(dst.Data)
+	activate(dst, l.Activation.or(LeakyReLU), l.Slope)
 	return dst
 }
 
-// Crossover performs crossover between two genomes
-func (l *FFN) Crossover(g1, g2 evolve.Genome) {
-	l1 := g1.(*FFN)
-	l2 := g2.(*FFN)
+// Record computes the outputs of the layer for the next step of a sequence, and records the
+// intermediate values on the tape for the back-propagation
+func (l *FFN) Record(x *math32.Matrix, t *Tape) *math32.Matrix {
+	s := t.next(x)
+	z := &s.v[0]
+	z.Reset(x.Rows, l.Wx.Cols)
+	math32.Matmul(z, x, &l.Wx)
+
+	s.y.Reset(z.Rows, z.Cols)
+	copy(s.y.Data, z.Data)
+	activate(&s.y, l.Activation.or(LeakyReLU), l.Slope)
+	return &s.y
+}
+
+// Backward back-propagates the gradients of the outputs of every recorded step, accumulates
+// the gradient of Wx into grads and returns the gradients of the inputs of every step
+func (l *FFN) Backward(t *Tape, dy []math32.Matrix, grads []*math32.Matrix) []math32.Matrix {
+	dx := t.backward(dy, grads, 1)
+	dz := &t.tmp[1]
+	for i := 0; i < t.n; i++ {
+		s := &t.steps[i]
+		derive(dz, &dy[i], &s.v[0], &s.y, l.Activation.or(LeakyReLU), l.Slope)
+		gradOf(grads[0], &dx[i], &s.x, &l.Wx, dz)
+	}
+	return dx
+}
 
+// Crossover performs crossover between two genomes
+func (l *FFN) Crossover(l1, l2 *FFN) {
 	crossoverMatrix(&l.Wx, &l1.Wx, &l2.Wx)
 }
 
+// Weights returns the weight matrices of the layer, in the order they are serialized
+func (l *FFN) Weights() []*math32.Matrix {
+	return []*math32.Matrix{&l.Wx}
+}
+
 // Mutate mutates the genome
 func (l *FFN) Mutate() {
 	const rate = 0.05
@@ -47,8 +78,15 @@ func (l *FFN) Mutate() {
 	mutateWeights(l.Wx.Data, rate)
 }
 
-func (l *FFN) Reset() {
-	// no recurrent state
+// Clone returns a copy of the layer
+func (l *FFN) Clone() *FFN {
+	return &FFN{
+		inputSize:  l.inputSize,
+		hiddenSize: l.hiddenSize,
+		Wx:         l.Wx.Clone(),
+		Activation: l.Activation,
+		Slope:      l.Slope,
+	}
 }
 
 // ---------------------------------- Evolution ----------------------------------
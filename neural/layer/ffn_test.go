@@ -19,7 +19,7 @@ func TestFFNUpdate(t *testing.T) {
 
 	x := math32.NewMatrix(1, 2, []float32{1, 2})
 	var dst math32.Matrix
-	out := l.Update(&dst, &x)
+	out := l.Forward(&dst, &x, nil)
 
 	expected := math32.NewMatrix(1, 3, nil)
 	math32.Matmul(&expected, &x, &l.Wx)
@@ -0,0 +1,205 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package layer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/kelindar/evolve/neural/math32"
+)
+
+// Tape records the intermediate values of the forward pass of a layer over the steps of a
+// sequence, starting from a zero state, so that the gradients can be back-propagated through
+// time. The recorded steps are kept between the sequences to avoid allocations.
+type Tape struct {
+	steps []step           // The recorded steps
+	dx    []math32.Matrix  // The gradients of the inputs of every step
+	n     int              // The number of recorded steps
+	zero  math32.Matrix    // The initial, zero state
+	tmp   [6]math32.Matrix // The scratch space
+}
+
+// step represents the intermediate values of a single step of the forward pass
+type step struct {
+	x, y math32.Matrix    // The input and the output of the step
+	v    [3]math32.Matrix // The intermediate values, such as the pre-activations or the gates
+}
+
+// Reset clears the recorded steps
+func (t *Tape) Reset() {
+	t.n = 0
+}
+
+// Len returns the number of recorded steps
+func (t *Tape) Len() int {
+	return t.n
+}
+
+// next records a new step with a copy of the input
+func (t *Tape) next(x *math32.Matrix) *step {
+	if t.n == len(t.steps) {
+		t.steps = append(t.steps, step{})
+	}
+
+	s := &t.steps[t.n]
+	s.x.Reset(x.Rows, x.Cols)
+	copy(s.x.Data, x.Data)
+	t.n++
+	return s
+}
+
+// prev returns the output of the step preceding the specified one, which is the recurrent
+// state of the specified size that the step was computed from
+func (t *Tape) prev(i, rows, cols int) *math32.Matrix {
+	if i > 0 {
+		return &t.steps[i-1].y
+	}
+	return resize(&t.zero, rows, cols)
+}
+
+// backward checks that the gradients of the outputs and the weights match the tape, and
+// returns the zeroed gradients of the inputs of every step
+func (t *Tape) backward(dy []math32.Matrix, grads []*math32.Matrix, weights int) []math32.Matrix {
+	switch {
+	case len(dy) != t.n:
+		panic(fmt.Errorf("layer: expected gradients for %d steps, got %d", t.n, len(dy)))
+	case len(grads) != weights:
+		panic(fmt.Errorf("layer: expected %d gradient matrices, got %d", weights, len(grads)))
+	}
+
+	for len(t.dx) < t.n {
+		t.dx = append(t.dx, math32.Matrix{})
+	}
+
+	for i := 0; i < t.n; i++ {
+		t.dx[i].Reset(t.steps[i].x.Rows, t.steps[i].x.Cols)
+	}
+	return t.dx[:t.n]
+}
+
+// carry returns the zeroed gradient of the recurrent state, which is carried backwards from
+// the last step
+func (t *Tape) carry(dy []math32.Matrix) *math32.Matrix {
+	dh := &t.tmp[0]
+	if len(dy) > 0 {
+		dh.Reset(dy[len(dy)-1].Rows, dy[len(dy)-1].Cols)
+	}
+	return dh
+}
+
+// ---------------------------------- Gradients ----------------------------------
+
+// derive computes the gradient of the pre-activations, given the gradient of the outputs and
+// both the pre-activations and the outputs of the activation function.
+func derive(dz, dy, z, y *math32.Matrix, fn Activation, slope float32) {
+	dz.Reset(dy.Rows, dy.Cols)
+	switch fn {
+	case Identity:
+		copy(dz.Data, dy.Data)
+	case ReLU:
+		for i, v := range z.Data {
+			if v > 0 {
+				dz.Data[i] = dy.Data[i]
+			}
+		}
+	case LeakyReLU:
+		if slope == 0 {
+			slope = 0.01
+		}
+
+		for i, v := range z.Data {
+			if v > 0 {
+				dz.Data[i] = dy.Data[i]
+			} else {
+				dz.Data[i] = dy.Data[i] * slope
+			}
+		}
+	case Tanh:
+		for i, v := range y.Data {
+			dz.Data[i] = dy.Data[i] * (1 - v*v)
+		}
+	case Sigmoid:
+		for i, v := range y.Data {
+			dz.Data[i] = dy.Data[i] * v * (1 - v)
+		}
+	case Swish:
+		for i, v := range z.Data {
+			s := sigmoid(v)
+			dz.Data[i] = dy.Data[i] * (s + v*s*(1-s))
+		}
+	case Softplus:
+		for i, v := range z.Data {
+			dz.Data[i] = dy.Data[i] * sigmoid(v)
+		}
+	case GELU:
+		const k, a = 1.5957691, 0.044715
+		for i, v := range z.Data {
+			s := sigmoid(k * (v + a*v*v*v))
+			dz.Data[i] = dy.Data[i] * (s + v*s*(1-s)*k*(1+3*a*v*v))
+		}
+	case Softmax:
+		for i := 0; i < len(y.Data); i += y.Cols {
+			out, grad := y.Data[i:i+y.Cols], dy.Data[i:i+y.Cols]
+			dot := float32(0)
+			for j, v := range out {
+				dot += v * grad[j]
+			}
+			for j, v := range out {
+				dz.Data[i+j] = v * (grad[j] - dot)
+			}
+		}
+	default:
+		panic(fmt.Errorf("layer: unknown activation %d", fn))
+	}
+}
+
+// gradOf accumulates the gradients of a weight matrix W and of the input x of the product
+// x·W, given the gradient of the product
+func gradOf(dw, dx, x, w, dz *math32.Matrix) {
+	matmulTN(dw, x, dz)
+	matmulNT(dx, dz, w)
+}
+
+// matmulTN performs the Mᵀ·N matrix multiplication and adds the result to dst
+func matmulTN(dst, m, n *math32.Matrix) {
+	for r := 0; r < m.Rows; r++ {
+		row := n.Data[r*n.Cols : (r+1)*n.Cols]
+		for i, v := range m.Data[r*m.Cols : (r+1)*m.Cols] {
+			if v != 0 {
+				math32.Axpy(dst.Data[i*dst.Cols:(i+1)*dst.Cols], row, v)
+			}
+		}
+	}
+}
+
+// matmulNT performs the M·Nᵀ matrix multiplication and adds the result to dst
+func matmulNT(dst, m, n *math32.Matrix) {
+	for r := 0; r < m.Rows; r++ {
+		row := m.Data[r*m.Cols : (r+1)*m.Cols]
+		for i := 0; i < n.Rows; i++ {
+			sum := float32(0)
+			for j, v := range n.Data[i*n.Cols : (i+1)*n.Cols] {
+				sum += row[j] * v
+			}
+			dst.Data[r*dst.Cols+i] += sum
+		}
+	}
+}
+
+// sumRows adds every row of the matrix, optionally multiplied element-wise by the rows of
+// another matrix, to the vector
+func sumRows(dst []float32, m, mul *math32.Matrix) {
+	for i, v := range m.Data {
+		if mul != nil {
+			v *= mul.Data[i]
+		}
+		dst[i%m.Cols] += v
+	}
+}
+
+// sigmoid computes the logistic function of a single value
+func sigmoid(x float32) float32 {
+	return 1 / (1 + float32(math.Exp(-float64(x))))
+}
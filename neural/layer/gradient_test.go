@@ -0,0 +1,74 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package layer
+
+import (
+	"testing"
+
+	"github.com/kelindar/evolve/neural/math32"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDerive(t *testing.T) {
+	const eps = 1e-2
+	z := math32.NewMatrix(2, 3, []float32{-1.5, -0.3, 0.2, 0.7, 1.1, 2})
+	dy := math32.NewMatrix(2, 3, []float32{1, -2, 0.5, 0.25, 1, -1})
+
+	for fn := Identity; fn <= Softmax; fn++ {
+		y := z.Clone()
+		activate(&y, fn, 0.1)
+
+		var dz math32.Matrix
+		derive(&dz, &dy, &z, &y, fn, 0.1)
+
+		// The gradient of Σ dy⊙f(z) must match its numerical gradient
+		for i := range z.Data {
+			loss := func(v float32) (sum float32) {
+				m := z.Clone()
+				m.Data[i] = v
+				activate(&m, fn, 0.1)
+				for j, out := range m.Data {
+					sum += out * dy.Data[j]
+				}
+				return
+			}
+
+			expect := (loss(z.Data[i]+eps) - loss(z.Data[i]-eps)) / (2 * eps)
+			assert.InDelta(t, expect, dz.Data[i], 1e-3, "%s at %d", fn, i)
+		}
+	}
+
+	assert.Panics(t, func() {
+		derive(&math32.Matrix{}, &math32.Matrix{}, &z, &z, Activation(100), 0)
+	})
+}
+
+func TestTape(t *testing.T) {
+	l := NewRNN(2, 3)
+	x := math32.NewMatrix(1, 2, []float32{1, -1})
+
+	// The recorded steps match the forward pass from a zero state
+	var tape Tape
+	var s State
+	var dst math32.Matrix
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, l.Forward(&dst, &x, &s).Data, l.Record(&x, &tape).Data)
+	}
+	assert.Equal(t, 3, tape.Len())
+
+	tape.Reset()
+	assert.Equal(t, 0, tape.Len())
+	s.Reset()
+	assert.Equal(t, l.Forward(&dst, &x, &s).Data, l.Record(&x, &tape).Data)
+
+	// The gradients must be provided for every step and every weight
+	dy := []math32.Matrix{math32.NewMatrix(1, 3, nil)}
+	grads := []*math32.Matrix{{}, {}, {}}
+	assert.Panics(t, func() {
+		l.Backward(&tape, nil, grads)
+	})
+	assert.Panics(t, func() {
+		l.Backward(&tape, dy, grads[:2])
+	})
+}
@@ -0,0 +1,129 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package layer
+
+import (
+	"github.com/kelindar/evolve/neural/math32"
+)
+
+// GRU is a gated recurrent unit layer, where every gate has a full hidden-to-hidden
+// recurrent matrix
+type GRU struct {
+	// Update gate parameters
+	Wz, Uz, Bz math32.Matrix
+
+	// Reset gate parameters
+	Wr, Ur, Br math32.Matrix
+
+	// Candidate state parameters
+	Wh, Uh, Bh math32.Matrix
+
+	// Activation of the candidate state (default: tanh)
+	Activation Activation
+	Slope      float32
+}
+
+// NewGRU creates a new GRU layer, based on https://arxiv.org/abs/1406.1078
+func NewGRU(inputSize, hiddenSize int) *GRU {
+	return &GRU{
+		Wz: math32.NewMatrixRandom(inputSize, hiddenSize),
+		Uz: math32.NewMatrixRandom(hiddenSize, hiddenSize),
+		Bz: math32.NewMatrixBias(1, hiddenSize),
+		Wr: math32.NewMatrixRandom(inputSize, hiddenSize),
+		Ur: math32.NewMatrixRandom(hiddenSize, hiddenSize),
+		Br: math32.NewMatrixBias(1, hiddenSize),
+		Wh: math32.NewMatrixRandom(inputSize, hiddenSize),
+		Uh: math32.NewMatrixRandom(hiddenSize, hiddenSize),
+		Bh: math32.NewMatrixBias(1, hiddenSize),
+	}
+}
+
+// Forward updates the specified hidden state with the input, without modifying the layer
+func (l *GRU) Forward(dst, x *math32.Matrix, s *State) *math32.Matrix {
+	h := resize(&s.h, x.Rows, l.Wz.Cols)
+	z, r, g, rh := &s.tmp[0], &s.tmp[1], &s.tmp[2], &s.tmp[3]
+
+	// ------------------------------------------------------------------
+	// Gates: z_t = σ(W_z·x_t + U_z·h_{t-1} + b_z), same for r_t
+	// ------------------------------------------------------------------
+	gate(z, x, h, &l.Wz, &l.Uz, &l.Bz)
+	gate(r, x, h, &l.Wr, &l.Ur, &l.Br)
+	math32.Sigmoid(z.Data)
+	math32.Sigmoid(r.Data)
+
+	// ------------------------------------------------------------------
+	// Candidate state: \tilde{h}_t = tanh(W_h·x_t + U_h·(r_t⊙h_{t-1}) + b_h)
+	// ------------------------------------------------------------------
+	rh.Reset(x.Rows, h.Cols)
+	copy(rh.Data, h.Data)
+	math32.Mul(rh.Data, r.Data)
+	gate(g, x, rh, &l.Wh, &l.Uh, &l.Bh)
+	activate(g, l.Activation.or(Tanh), l.Slope)
+
+	// ------------------------------------------------------------------
+	// Final state: h_t = (1-z_t)⊙h_{t-1} + z_t⊙\tilde{h}_t
+	// ------------------------------------------------------------------
+	dst.Reset(x.Rows, h.Cols)
+	for i, zt := range z.Data {
+		dst.Data[i] = (1-zt)*h.Data[i] + zt*g.Data[i]
+	}
+
+	copy(h.Data, dst.Data)
+	return dst
+}
+
+// Crossover performs crossover between two genomes
+func (l *GRU) Crossover(l1, l2 *GRU) {
+	crossoverMatrix(&l.Wz, &l1.Wz, &l2.Wz)
+	crossoverMatrix(&l.Uz, &l1.Uz, &l2.Uz)
+	crossoverMatrix(&l.Bz, &l1.Bz, &l2.Bz)
+
+	crossoverMatrix(&l.Wr, &l1.Wr, &l2.Wr)
+	crossoverMatrix(&l.Ur, &l1.Ur, &l2.Ur)
+	crossoverMatrix(&l.Br, &l1.Br, &l2.Br)
+
+	crossoverMatrix(&l.Wh, &l1.Wh, &l2.Wh)
+	crossoverMatrix(&l.Uh, &l1.Uh, &l2.Uh)
+	crossoverMatrix(&l.Bh, &l1.Bh, &l2.Bh)
+}
+
+// Weights returns the weight matrices of the layer, in the order they are serialized
+func (l *GRU) Weights() []*math32.Matrix {
+	return []*math32.Matrix{&l.Wz, &l.Uz, &l.Bz, &l.Wr, &l.Ur, &l.Br, &l.Wh, &l.Uh, &l.Bh}
+}
+
+// Mutate mutates the genome
+func (l *GRU) Mutate() {
+	const rate = 0.05
+
+	mutateWeights(l.Wz.Data, rate)
+	mutateWeights(l.Uz.Data, rate)
+	mutateBias(l.Bz.Data, rate)
+
+	mutateWeights(l.Wr.Data, rate)
+	mutateWeights(l.Ur.Data, rate)
+	mutateBias(l.Br.Data, rate)
+
+	mutateWeights(l.Wh.Data, rate)
+	mutateWeights(l.Uh.Data, rate)
+	mutateBias(l.Bh.Data, rate)
+}
+
+// Clone returns a copy of the layer
+func (l *GRU) Clone() *GRU {
+	return &GRU{
+		Wz: l.Wz.Clone(),
+		Uz: l.Uz.Clone(),
+		Bz: l.Bz.Clone(),
+		Wr: l.Wr.Clone(),
+		Ur: l.Ur.Clone(),
+		Br: l.Br.Clone(),
+		Wh: l.Wh.Clone(),
+		Uh: l.Uh.Clone(),
+		Bh: l.Bh.Clone(),
+
+		Activation: l.Activation,
+		Slope:      l.Slope,
+	}
+}
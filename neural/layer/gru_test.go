@@ -0,0 +1,64 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package layer
+
+import (
+	"testing"
+
+	"github.com/kelindar/evolve/neural/math32"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGRUUpdate(t *testing.T) {
+	l := NewGRU(3, 4)
+	x := math32.NewMatrix(1, 3, []float32{1, -0.5, 2})
+	h := make([]float32, 4)
+
+	var s State
+	var dst math32.Matrix
+	for step := 0; step < 3; step++ {
+		h = manualGRUStep(l, x.Data, h)
+		out := l.Forward(&dst, &x, &s)
+		assert.InDeltaSlice(t, h, out.Data, 1e-5)
+	}
+
+	// The state is cleared on reset
+	s.Reset()
+	h = manualGRUStep(l, x.Data, make([]float32, 4))
+	assert.InDeltaSlice(t, h, l.Forward(&dst, &x, &s).Data, 1e-5)
+}
+
+func TestGRUClone(t *testing.T) {
+	l1, l2 := NewGRU(2, 3), NewGRU(2, 3)
+	x := math32.NewMatrix(1, 2, []float32{1, 2})
+
+	var s1, s2 State
+	var dst1, dst2 math32.Matrix
+	clone := l1.Clone()
+	assert.Equal(t, l1.Uz, clone.Uz)
+	assert.Equal(t, l1.Forward(&dst1, &x, &s1).Data, clone.Forward(&dst2, &x, &s2).Data)
+
+	// Crossover blends both parents and mutation keeps the shapes
+	clone.Crossover(l1, l2)
+	assert.InDelta(t, 0.75*l1.Uh.Data[0]+0.25*l2.Uh.Data[0], clone.Uh.Data[0], 1e-6)
+	clone.Mutate()
+	assert.Len(t, clone.Ur.Data, 9)
+}
+
+func manualGRUStep(l *GRU, x, h []float32) []float32 {
+	z := sigmoidOf(gateOf(&l.Wz, &l.Uz, &l.Bz, x, h))
+	r := sigmoidOf(gateOf(&l.Wr, &l.Ur, &l.Br, x, h))
+
+	rh := make([]float32, len(h))
+	for k := range rh {
+		rh[k] = r[k] * h[k]
+	}
+
+	g := tanhOf(gateOf(&l.Wh, &l.Uh, &l.Bh, x, rh))
+	out := make([]float32, len(h))
+	for k := range out {
+		out[k] = (1-z[k])*h[k] + z[k]*g[k]
+	}
+	return out
+}
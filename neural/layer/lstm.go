@@ -0,0 +1,155 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package layer
+
+import (
+	"github.com/kelindar/evolve/neural/math32"
+)
+
+// LSTM is a long short-term memory layer, where every gate has a full hidden-to-hidden
+// recurrent matrix
+type LSTM struct {
+	// Input gate parameters
+	Wi, Ui, Bi math32.Matrix
+
+	// Forget gate parameters
+	Wf, Uf, Bf math32.Matrix
+
+	// Output gate parameters
+	Wo, Uo, Bo math32.Matrix
+
+	// Candidate cell parameters
+	Wc, Uc, Bc math32.Matrix
+
+	// Activation of the candidate cell and of the cell output (default: tanh)
+	Activation Activation
+	Slope      float32
+}
+
+// NewLSTM creates a new LSTM layer, based on https://www.bioinf.jku.at/publications/older/2604.pdf
+// with the forget gate of https://doi.org/10.1162/089976600300015015
+func NewLSTM(inputSize, hiddenSize int) *LSTM {
+	return &LSTM{
+		Wi: math32.NewMatrixRandom(inputSize, hiddenSize),
+		Ui: math32.NewMatrixRandom(hiddenSize, hiddenSize),
+		Bi: math32.NewMatrixBias(1, hiddenSize),
+		Wf: math32.NewMatrixRandom(inputSize, hiddenSize),
+		Uf: math32.NewMatrixRandom(hiddenSize, hiddenSize),
+		Bf: math32.NewMatrixBias(1, hiddenSize),
+		Wo: math32.NewMatrixRandom(inputSize, hiddenSize),
+		Uo: math32.NewMatrixRandom(hiddenSize, hiddenSize),
+		Bo: math32.NewMatrixBias(1, hiddenSize),
+		Wc: math32.NewMatrixRandom(inputSize, hiddenSize),
+		Uc: math32.NewMatrixRandom(hiddenSize, hiddenSize),
+		Bc: math32.NewMatrixBias(1, hiddenSize),
+	}
+}
+
+// Forward updates the specified hidden and cell state with the input, without modifying the layer
+func (l *LSTM) Forward(dst, x *math32.Matrix, s *State) *math32.Matrix {
+	activation := l.Activation.or(Tanh)
+	h := resize(&s.h, x.Rows, l.Wi.Cols)
+	c := resize(&s.c, x.Rows, l.Wi.Cols)
+	i, f, o, g := &s.tmp[0], &s.tmp[1], &s.tmp[2], &s.tmp[3]
+
+	// ------------------------------------------------------------------
+	// Gates: i_t = σ(W_i·x_t + U_i·h_{t-1} + b_i), same for f_t and o_t
+	// ------------------------------------------------------------------
+	gate(i, x, h, &l.Wi, &l.Ui, &l.Bi)
+	gate(f, x, h, &l.Wf, &l.Uf, &l.Bf)
+	gate(o, x, h, &l.Wo, &l.Uo, &l.Bo)
+	math32.Sigmoid(i.Data)
+	math32.Sigmoid(f.Data)
+	math32.Sigmoid(o.Data)
+
+	// ------------------------------------------------------------------
+	// Candidate cell: \tilde{c}_t = tanh(W_c·x_t + U_c·h_{t-1} + b_c)
+	// ------------------------------------------------------------------
+	gate(g, x, h, &l.Wc, &l.Uc, &l.Bc)
+	activate(g, activation, l.Slope)
+
+	// ------------------------------------------------------------------
+	// Cell state: c_t = f_t⊙c_{t-1} + i_t⊙\tilde{c}_t
+	// ------------------------------------------------------------------
+	math32.Mul(c.Data, f.Data)
+	math32.Mul(g.Data, i.Data)
+	math32.Add(c.Data, g.Data)
+
+	// ------------------------------------------------------------------
+	// Final state: h_t = o_t⊙tanh(c_t)
+	// ------------------------------------------------------------------
+	dst.Reset(x.Rows, h.Cols)
+	copy(dst.Data, c.Data)
+	activate(dst, activation, l.Slope)
+	math32.Mul(dst.Data, o.Data)
+
+	copy(h.Data, dst.Data)
+	return dst
+}
+
+// Crossover performs crossover between two genomes
+func (l *LSTM) Crossover(l1, l2 *LSTM) {
+	crossoverMatrix(&l.Wi, &l1.Wi, &l2.Wi)
+	crossoverMatrix(&l.Ui, &l1.Ui, &l2.Ui)
+	crossoverMatrix(&l.Bi, &l1.Bi, &l2.Bi)
+
+	crossoverMatrix(&l.Wf, &l1.Wf, &l2.Wf)
+	crossoverMatrix(&l.Uf, &l1.Uf, &l2.Uf)
+	crossoverMatrix(&l.Bf, &l1.Bf, &l2.Bf)
+
+	crossoverMatrix(&l.Wo, &l1.Wo, &l2.Wo)
+	crossoverMatrix(&l.Uo, &l1.Uo, &l2.Uo)
+	crossoverMatrix(&l.Bo, &l1.Bo, &l2.Bo)
+
+	crossoverMatrix(&l.Wc, &l1.Wc, &l2.Wc)
+	crossoverMatrix(&l.Uc, &l1.Uc, &l2.Uc)
+	crossoverMatrix(&l.Bc, &l1.Bc, &l2.Bc)
+}
+
+// Weights returns the weight matrices of the layer, in the order they are serialized
+func (l *LSTM) Weights() []*math32.Matrix {
+	return []*math32.Matrix{&l.Wi, &l.Ui, &l.Bi, &l.Wf, &l.Uf, &l.Bf, &l.Wo, &l.Uo, &l.Bo, &l.Wc, &l.Uc, &l.Bc}
+}
+
+// Mutate mutates the genome
+func (l *LSTM) Mutate() {
+	const rate = 0.05
+
+	mutateWeights(l.Wi.Data, rate)
+	mutateWeights(l.Ui.Data, rate)
+	mutateBias(l.Bi.Data, rate)
+
+	mutateWeights(l.Wf.Data, rate)
+	mutateWeights(l.Uf.Data, rate)
+	mutateBias(l.Bf.Data, rate)
+
+	mutateWeights(l.Wo.Data, rate)
+	mutateWeights(l.Uo.Data, rate)
+	mutateBias(l.Bo.Data, rate)
+
+	mutateWeights(l.Wc.Data, rate)
+	mutateWeights(l.Uc.Data, rate)
+	mutateBias(l.Bc.Data, rate)
+}
+
+// Clone returns a copy of the layer
+func (l *LSTM) Clone() *LSTM {
+	return &LSTM{
+		Wi: l.Wi.Clone(),
+		Ui: l.Ui.Clone(),
+		Bi: l.Bi.Clone(),
+		Wf: l.Wf.Clone(),
+		Uf: l.Uf.Clone(),
+		Bf: l.Bf.Clone(),
+		Wo: l.Wo.Clone(),
+		Uo: l.Uo.Clone(),
+		Bo: l.Bo.Clone(),
+		Wc: l.Wc.Clone(),
+		Uc: l.Uc.Clone(),
+		Bc: l.Bc.Clone(),
+
+		Activation: l.Activation,
+		Slope:      l.Slope,
+	}
+}
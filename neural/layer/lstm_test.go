@@ -0,0 +1,94 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package layer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kelindar/evolve/neural/math32"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLSTMUpdate(t *testing.T) {
+	l := NewLSTM(3, 4)
+	x := math32.NewMatrix(1, 3, []float32{1, -0.5, 2})
+	h, c := make([]float32, 4), make([]float32, 4)
+
+	var s State
+	var dst math32.Matrix
+	for step := 0; step < 3; step++ {
+		h, c = manualLSTMStep(l, x.Data, h, c)
+		out := l.Forward(&dst, &x, &s)
+		assert.InDeltaSlice(t, h, out.Data, 1e-5)
+	}
+
+	// The state is cleared on reset
+	s.Reset()
+	h, _ = manualLSTMStep(l, x.Data, make([]float32, 4), make([]float32, 4))
+	assert.InDeltaSlice(t, h, l.Forward(&dst, &x, &s).Data, 1e-5)
+}
+
+func TestLSTMClone(t *testing.T) {
+	l1, l2 := NewLSTM(2, 3), NewLSTM(2, 3)
+	x := math32.NewMatrix(1, 2, []float32{1, 2})
+
+	var s1, s2 State
+	var dst1, dst2 math32.Matrix
+	clone := l1.Clone()
+	assert.Equal(t, l1.Ui, clone.Ui)
+	assert.Equal(t, l1.Forward(&dst1, &x, &s1).Data, clone.Forward(&dst2, &x, &s2).Data)
+
+	// Crossover blends both parents and mutation keeps the shapes
+	clone.Crossover(l1, l2)
+	assert.InDelta(t, 0.75*l1.Uc.Data[0]+0.25*l2.Uc.Data[0], clone.Uc.Data[0], 1e-6)
+	clone.Mutate()
+	assert.Len(t, clone.Uo.Data, 9)
+}
+
+// gateOf computes W·x + U·h + b with plain loops
+func gateOf(w, u, b *math32.Matrix, x, h []float32) []float32 {
+	out := append([]float32(nil), b.Data...)
+	for j := range out {
+		for i, v := range x {
+			out[j] += v * w.Data[i*w.Cols+j]
+		}
+		for i, v := range h {
+			out[j] += v * u.Data[i*u.Cols+j]
+		}
+	}
+	return out
+}
+
+func sigmoidOf(v []float32) []float32 {
+	for i, x := range v {
+		v[i] = float32(1 / (1 + math.Exp(-float64(x))))
+	}
+	return v
+}
+
+func tanhOf(v []float32) []float32 {
+	for i, x := range v {
+		v[i] = float32(math.Tanh(float64(x)))
+	}
+	return v
+}
+
+func manualLSTMStep(l *LSTM, x, h, c []float32) ([]float32, []float32) {
+	i := sigmoidOf(gateOf(&l.Wi, &l.Ui, &l.Bi, x, h))
+	f := sigmoidOf(gateOf(&l.Wf, &l.Uf, &l.Bf, x, h))
+	o := sigmoidOf(gateOf(&l.Wo, &l.Uo, &l.Bo, x, h))
+	g := tanhOf(gateOf(&l.Wc, &l.Uc, &l.Bc, x, h))
+
+	cell := make([]float32, len(c))
+	for k := range cell {
+		cell[k] = f[k]*c[k] + i[k]*g[k]
+	}
+
+	out := tanhOf(append([]float32(nil), cell...))
+	for k := range out {
+		out[k] *= o[k]
+	}
+	return out, cell
+}
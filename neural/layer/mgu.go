@@ -4,21 +4,23 @@
 package layer
 
 import (
-	"github.com/kelindar/evolve"
 	"github.com/kelindar/evolve/neural/math32"
 )
 
 // MGU is a minimal gated recurrent unit layer
 type MGU struct {
-	// Forget gate parameters
+	// Forget gate parameters, where Uf and Uh are vectors unless dense
 	Wf, Uf, Bf math32.Matrix
 
 	// Candidate state parameters
 	Wh, Uh, Bh math32.Matrix
 
-	// Internal state and scratch space
-	h  math32.Matrix
-	hc math32.Matrix
+	// Whether the hidden units are fully connected to each other
+	dense bool
+
+	// Activation of the candidate state (default: tanh)
+	Activation Activation
+	Slope      float32
 }
 
 // NewMGU creates a new MGU layer, based on https://arxiv.org/abs/1603.09420 and https://arxiv.org/abs/1701.03452
@@ -30,55 +32,181 @@ func NewMGU(inputSize, hiddenSize int) *MGU {
 		Uh: math32.NewMatrixRandom(1, hiddenSize),
 		Bf: math32.NewMatrixBias(1, hiddenSize),
 		Bh: math32.NewMatrixBias(1, hiddenSize),
-		h:  math32.NewMatrix(1, hiddenSize, nil),
-		hc: math32.NewMatrix(1, hiddenSize, nil),
 	}
 }
 
-func (l *MGU) Update(dst, x *math32.Matrix) *math32.Matrix {
-	dst.Reset(x.Rows, l.h.Cols)
+// NewDenseMGU creates a new MGU layer with dense hidden-to-hidden recurrent matrices, so that
+// every hidden unit depends on the previous state of all of the hidden units
+func NewDenseMGU(inputSize, hiddenSize int) *MGU {
+	return &MGU{
+		Wf:    math32.NewMatrixRandom(inputSize, hiddenSize),
+		Wh:    math32.NewMatrixRandom(inputSize, hiddenSize),
+		Uf:    math32.NewMatrixRandom(hiddenSize, hiddenSize),
+		Uh:    math32.NewMatrixRandom(hiddenSize, hiddenSize),
+		Bf:    math32.NewMatrixBias(1, hiddenSize),
+		Bh:    math32.NewMatrixBias(1, hiddenSize),
+		dense: true,
+	}
+}
+
+// Dense returns whether the layer has dense recurrent matrices
+func (l *MGU) Dense() bool {
+	return l.dense
+}
+
+// Forward updates the specified hidden state with the input, without modifying the layer
+func (l *MGU) Forward(dst, x *math32.Matrix, s *State) *math32.Matrix {
+	dst.Reset(x.Rows, l.Wf.Cols)
+	h := resize(&s.h, x.Rows, l.Wf.Cols)
 
 	// ------------------------------------------------------------------
 	// Forget gate: f_t = σ(W_f·x_t + U_f⊙h_{t-1} + b_f)
 	// ------------------------------------------------------------------
 	math32.Matmul(dst, x, &l.Wf)
-	tmp := append([]float32(nil), l.h.Data...)
-	math32.Mul(tmp, l.Uf.Data)
-	math32.Add(dst.Data, tmp)
-	math32.Add(dst.Data, l.Bf.Data)
+	tmp := &s.tmp[0]
+	tmp.Reset(x.Rows, h.Cols)
+	copy(tmp.Data, h.Data)
+	l.recur(dst, tmp, &l.Uf)
+	addRows(dst, l.Bf.Data)
 	math32.Sigmoid(dst.Data)
 	f := dst.Data
 
 	// ------------------------------------------------------------------
 	// Candidate state: \tilde{h}_t = tanh(W_h·x_t + U_h⊙(f_t⊙h_{t-1}) + b_h)
 	// ------------------------------------------------------------------
-	hc := &l.hc
-	hc.Reset(x.Rows, l.h.Cols)
+	hc := &s.tmp[1]
+	hc.Reset(x.Rows, h.Cols)
 	math32.Matmul(hc, x, &l.Wh)
 
-	tmp = append(tmp[:0], l.h.Data...)
-	math32.Mul(tmp, f)
-	math32.Mul(tmp, l.Uh.Data)
-	math32.Add(hc.Data, tmp)
-	math32.Add(hc.Data, l.Bh.Data)
-	math32.Tanh(hc.Data)
+	copy(tmp.Data, h.Data)
+	math32.Mul(tmp.Data, f)
+	l.recur(hc, tmp, &l.Uh)
+	addRows(hc, l.Bh.Data)
+	activate(hc, l.Activation.or(Tanh), l.Slope)
 
 	// ------------------------------------------------------------------
 	// Final state: h_t = (1-f_t)⊙h_{t-1} + f_t⊙\tilde{h}_t
 	// ------------------------------------------------------------------
 	for i, ft := range f {
-		dst.Data[i] = (1-ft)*l.h.Data[i] + ft*hc.Data[i]
+		dst.Data[i] = (1-ft)*h.Data[i] + ft*hc.Data[i]
 	}
 
-	copy(l.h.Data, dst.Data)
+	copy(h.Data, dst.Data)
 	return dst
 }
 
-// Crossover performs crossover between two genomes
-func (l *MGU) Crossover(g1, g2 evolve.Genome) {
-	l1 := g1.(*MGU)
-	l2 := g2.(*MGU)
+// Record computes the outputs of the layer for the next step of a sequence, and records the
+// intermediate values on the tape for the back-propagation through time
+func (l *MGU) Record(x *math32.Matrix, t *Tape) *math32.Matrix {
+	h := t.prev(t.n, x.Rows, l.Wf.Cols)
+	s := t.next(x)
+	f, a, c := &s.v[0], &s.v[1], &s.v[2]
+	tmp := &t.tmp[0]
+	tmp.Reset(h.Rows, h.Cols)
+
+	// Forget gate: f_t = σ(W_f·x_t + U_f⊙h_{t-1} + b_f)
+	f.Reset(x.Rows, l.Wf.Cols)
+	math32.Matmul(f, x, &l.Wf)
+	copy(tmp.Data, h.Data)
+	l.recur(f, tmp, &l.Uf)
+	addRows(f, l.Bf.Data)
+	math32.Sigmoid(f.Data)
+
+	// Candidate state: \tilde{h}_t = tanh(W_h·x_t + U_h⊙(f_t⊙h_{t-1}) + b_h)
+	a.Reset(x.Rows, l.Wh.Cols)
+	math32.Matmul(a, x, &l.Wh)
+	copy(tmp.Data, h.Data)
+	math32.Mul(tmp.Data, f.Data)
+	l.recur(a, tmp, &l.Uh)
+	addRows(a, l.Bh.Data)
+	c.Reset(a.Rows, a.Cols)
+	copy(c.Data, a.Data)
+	activate(c, l.Activation.or(Tanh), l.Slope)
+
+	// Final state: h_t = (1-f_t)⊙h_{t-1} + f_t⊙\tilde{h}_t
+	s.y.Reset(x.Rows, h.Cols)
+	for i, ft := range f.Data {
+		s.y.Data[i] = (1-ft)*h.Data[i] + ft*c.Data[i]
+	}
+	return &s.y
+}
 
+// Backward back-propagates the gradients of the outputs of every recorded step through time,
+// accumulates the gradients of Wf, Uf, Bf, Wh, Uh and Bh into grads and returns the gradients
+// of the inputs of every step
+func (l *MGU) Backward(t *Tape, dy []math32.Matrix, grads []*math32.Matrix) []math32.Matrix {
+	dx := t.backward(dy, grads, 6)
+	dh := t.carry(dy)
+	dc, da, df, hf, dhf := &t.tmp[1], &t.tmp[2], &t.tmp[3], &t.tmp[4], &t.tmp[5]
+	for i := t.n - 1; i >= 0; i-- {
+		s := &t.steps[i]
+		h := t.prev(i, s.y.Rows, s.y.Cols)
+		f, a, c := &s.v[0], &s.v[1], &s.v[2]
+
+		// Final state, where the state is used by the next step as well as by the next layer
+		math32.Add(dh.Data, dy[i].Data)
+		dc.Reset(dh.Rows, dh.Cols)
+		df.Reset(dh.Rows, dh.Cols)
+		for j, g := range dh.Data {
+			dc.Data[j] = g * f.Data[j]
+			df.Data[j] = g * (c.Data[j] - h.Data[j])
+			dh.Data[j] = g * (1 - f.Data[j])
+		}
+
+		// Candidate state, whose recurrent input is the gated state f_t⊙h_{t-1}
+		derive(da, dc, a, c, l.Activation.or(Tanh), l.Slope)
+		gradOf(grads[3], &dx[i], &s.x, &l.Wh, da)
+		sumRows(grads[5].Data, da, nil)
+		hf.Reset(h.Rows, h.Cols)
+		copy(hf.Data, h.Data)
+		math32.Mul(hf.Data, f.Data)
+		l.unrecur(grads[4], dhf, hf, &l.Uh, da)
+		for j, v := range dhf.Data {
+			df.Data[j] += v * h.Data[j]
+			dh.Data[j] += v * f.Data[j]
+		}
+
+		// Forget gate
+		for j, ft := range f.Data {
+			df.Data[j] *= ft * (1 - ft)
+		}
+
+		gradOf(grads[0], &dx[i], &s.x, &l.Wf, df)
+		sumRows(grads[2].Data, df, nil)
+		l.unrecur(grads[1], dhf, h, &l.Uf, df)
+		math32.Add(dh.Data, dhf.Data)
+	}
+	return dx
+}
+
+// recur adds the recurrent term U·h (or U⊙h if not dense) to the destination, where the
+// state is used as a scratch space
+func (l *MGU) recur(dst, h, u *math32.Matrix) {
+	if l.dense {
+		math32.Matmul(dst, h, u)
+		return
+	}
+
+	mulRows(h, u.Data)
+	math32.Add(dst.Data, h.Data)
+}
+
+// unrecur back-propagates the gradient of the recurrent term through U, accumulating the
+// gradient of U and writing the gradient of the state into dh
+func (l *MGU) unrecur(du, dh, h, u, dz *math32.Matrix) {
+	dh.Reset(dz.Rows, dz.Cols)
+	if l.dense {
+		gradOf(du, dh, h, u, dz)
+		return
+	}
+
+	sumRows(du.Data, dz, h)
+	copy(dh.Data, dz.Data)
+	mulRows(dh, u.Data)
+}
+
+// Crossover performs crossover between two genomes
+func (l *MGU) Crossover(l1, l2 *MGU) {
 	crossoverMatrix(&l.Wf, &l1.Wf, &l2.Wf)
 	crossoverMatrix(&l.Uf, &l1.Uf, &l2.Uf)
 	crossoverMatrix(&l.Bf, &l1.Bf, &l2.Bf)
@@ -88,6 +216,11 @@ func (l *MGU) Crossover(g1, g2 evolve.Genome) {
 	crossoverMatrix(&l.Bh, &l1.Bh, &l2.Bh)
 }
 
+// Weights returns the weight matrices of the layer, in the order they are serialized
+func (l *MGU) Weights() []*math32.Matrix {
+	return []*math32.Matrix{&l.Wf, &l.Uf, &l.Bf, &l.Wh, &l.Uh, &l.Bh}
+}
+
 // Mutate mutates the genome
 func (l *MGU) Mutate() {
 	const rate = 0.05
@@ -101,6 +234,18 @@ func (l *MGU) Mutate() {
 	mutateBias(l.Bh.Data, rate)
 }
 
-func (l *MGU) Reset() {
-	l.h.Zero()
+// Clone returns a copy of the layer
+func (l *MGU) Clone() *MGU {
+	return &MGU{
+		Wf: l.Wf.Clone(),
+		Uf: l.Uf.Clone(),
+		Bf: l.Bf.Clone(),
+		Wh: l.Wh.Clone(),
+		Uh: l.Uh.Clone(),
+		Bh: l.Bh.Clone(),
+
+		dense:      l.dense,
+		Activation: l.Activation,
+		Slope:      l.Slope,
+	}
 }
@@ -21,22 +21,21 @@ func TestMGUUpdate(t *testing.T) {
 		Uh: math32.NewMatrix(1, 2, []float32{0.1, 0.1}),
 		Bf: math32.NewMatrix(1, 2, []float32{0, 0}),
 		Bh: math32.NewMatrix(1, 2, []float32{0, 0}),
-		h:  math32.NewMatrix(1, 2, nil),
-		hc: math32.NewMatrix(1, 2, nil),
 	}
 
 	x := math32.NewMatrix(1, 2, []float32{1, 2})
+	var s State
 	var dst math32.Matrix
 
 	// first step
-	out1 := m.Update(&dst, &x)
+	out1 := m.Forward(&dst, &x, &s)
 	expected1 := manualMGUStep(&m.Wf, &m.Uf, &m.Bf, &m.Wh, &m.Uh, &m.Bh, x.Data, []float32{0, 0})
 	assert.InDeltaSlice(t, expected1, out1.Data, 1e-5)
 
 	// second step
 	prev := append([]float32(nil), out1.Data...)
 	expected2 := manualMGUStep(&m.Wf, &m.Uf, &m.Bf, &m.Wh, &m.Uh, &m.Bh, x.Data, prev)
-	out2 := m.Update(&dst, &x)
+	out2 := m.Forward(&dst, &x, &s)
 	assert.InDeltaSlice(t, expected2, out2.Data, 1e-5)
 }
 
@@ -66,3 +65,32 @@ func manualMGUStep(Wf, Uf, Bf, Wh, Uh, Bh *math32.Matrix, x, hPrev []float32) []
 	}
 	return out
 }
+
+func TestMGUDense(t *testing.T) {
+	m := NewDenseMGU(3, 4)
+	assert.True(t, m.Dense())
+	assert.Equal(t, 4, m.Uf.Rows)
+	assert.Equal(t, 4, m.Uh.Rows)
+	assert.False(t, NewMGU(3, 4).Dense())
+
+	x := math32.NewMatrix(1, 3, []float32{1, -0.5, 2})
+	h := make([]float32, 4)
+
+	var s State
+	var dst math32.Matrix
+	for step := 0; step < 3; step++ {
+		f := sigmoidOf(gateOf(&m.Wf, &m.Uf, &m.Bf, x.Data, h))
+		fh := make([]float32, len(h))
+		for i := range fh {
+			fh[i] = f[i] * h[i]
+		}
+
+		hc := tanhOf(gateOf(&m.Wh, &m.Uh, &m.Bh, x.Data, fh))
+		for i := range h {
+			h[i] = (1-f[i])*h[i] + f[i]*hc[i]
+		}
+		assert.InDeltaSlice(t, h, m.Forward(&dst, &x, &s).Data, 1e-5)
+	}
+
+	assert.True(t, m.Clone().Dense())
+}
@@ -4,15 +4,18 @@
 package layer
 
 import (
-	"github.com/kelindar/evolve"
 	"github.com/kelindar/evolve/neural/math32"
 )
 
 type RNN struct {
 	Wx math32.Matrix // input weights
-	Wh math32.Matrix // hidden state weights (recurrent weight u)
+	Wh math32.Matrix // hidden state weights (recurrent weight u, or a matrix if dense)
 	Bh math32.Matrix // bias
-	h  math32.Matrix // hidden state
+
+	dense bool // whether the hidden units are fully connected to each other
+
+	Activation Activation // activation of the outputs (default: leaky ReLU)
+	Slope      float32    // slope of the leaky ReLU (default: 0.01)
 }
 
 // NewRNN creates a new RNN layer, based on https://arxiv.org/pdf/1803.04831.pdf
@@ -21,37 +24,115 @@ func NewRNN(inputSize, hiddenSize int) *RNN {
 		Wx: math32.NewMatrixRandom(inputSize, hiddenSize),
 		Wh: math32.NewMatrixRandom(1, hiddenSize),
 		Bh: math32.NewMatrixBias(1, hiddenSize),
-		h:  math32.NewMatrix(1, hiddenSize, nil),
 	}
 }
 
-func (l *RNN) Update(dst, x *math32.Matrix) *math32.Matrix {
+// NewDenseRNN creates a new RNN layer with a dense hidden-to-hidden recurrent matrix, so that
+// every hidden unit depends on the previous state of all of the hidden units
+func NewDenseRNN(inputSize, hiddenSize int) *RNN {
+	return &RNN{
+		Wx:    math32.NewMatrixRandom(inputSize, hiddenSize),
+		Wh:    math32.NewMatrixRandom(hiddenSize, hiddenSize),
+		Bh:    math32.NewMatrixBias(1, hiddenSize),
+		dense: true,
+	}
+}
+
+// Dense returns whether the layer has a dense recurrent matrix
+func (l *RNN) Dense() bool {
+	return l.dense
+}
+
+// Forward updates the specified hidden state with the input, without modifying the layer
+func (l *RNN) Forward(dst, x *math32.Matrix, s *State) *math32.Matrix {
 	dst.Reset(x.Rows, l.Wx.Cols)
+	h := resize(&s.h, x.Rows, l.Wx.Cols)
 
 	// https://github.com/batzner/indrnn/blob/master/ind_rnn_cell.py
 	// https://arxiv.org/pdf/1803.04831.pdf
 	// ht = σ(Wxt + u·ht−1 + b)
-	math32.Matmul(dst, x, &l.Wx)    // (1) = Wxt
-	math32.Mul(l.h.Data, l.Wh.Data) // (2) = u·ht−1
-	math32.Add(dst.Data, l.h.Data)  // (3) = (1) + (2)
-	math32.Add(dst.Data, l.Bh.Data) // (4) = (3) + bias
-	math32.Lrelu(dst.Data)          // (5) = σ(4)
+	math32.Matmul(dst, x, &l.Wx) // (1) = Wxt
+	if l.dense {
+		math32.Matmul(dst, h, &l.Wh) // (3) = (1) + U·ht−1, where U is a matrix
+	} else {
+		mulRows(h, l.Wh.Data)        // (2) = u·ht−1
+		math32.Add(dst.Data, h.Data) // (3) = (1) + (2)
+	}
+
+	addRows(dst, l.Bh.Data)                            // (4) = (3) + bias
+	activate(dst, l.Activation.or(LeakyReLU), l.Slope) // (5) = σ(4)
 
 	// Remember the hidden state for the next time step
-	copy(l.h.Data, dst.Data)
+	copy(h.Data, dst.Data)
 	return dst
 }
 
-// Crossover performs crossover between two genomes
-func (l *RNN) Crossover(g1, g2 evolve.Genome) {
-	l1 := g1.(*RNN)
-	l2 := g2.(*RNN)
+// Record computes the outputs of the layer for the next step of a sequence, and records the
+// intermediate values on the tape for the back-propagation through time
+func (l *RNN) Record(x *math32.Matrix, t *Tape) *math32.Matrix {
+	h := t.prev(t.n, x.Rows, l.Wx.Cols)
+	s := t.next(x)
+	z := &s.v[0]
+	z.Reset(x.Rows, l.Wx.Cols)
+	math32.Matmul(z, x, &l.Wx)
+	if l.dense {
+		math32.Matmul(z, h, &l.Wh)
+	} else {
+		uh := &s.v[1]
+		uh.Reset(h.Rows, h.Cols)
+		copy(uh.Data, h.Data)
+		mulRows(uh, l.Wh.Data)
+		math32.Add(z.Data, uh.Data)
+	}
 
+	addRows(z, l.Bh.Data)
+	s.y.Reset(z.Rows, z.Cols)
+	copy(s.y.Data, z.Data)
+	activate(&s.y, l.Activation.or(LeakyReLU), l.Slope)
+	return &s.y
+}
+
+// Backward back-propagates the gradients of the outputs of every recorded step through time,
+// accumulates the gradients of Wx, Wh and Bh into grads and returns the gradients of the
+// inputs of every step
+func (l *RNN) Backward(t *Tape, dy []math32.Matrix, grads []*math32.Matrix) []math32.Matrix {
+	dx := t.backward(dy, grads, 3)
+	dh, dz := t.carry(dy), &t.tmp[1]
+	for i := t.n - 1; i >= 0; i-- {
+		s := &t.steps[i]
+		h := t.prev(i, s.y.Rows, s.y.Cols)
+
+		// The state is used by the next step as well as by the next layer
+		math32.Add(dh.Data, dy[i].Data)
+		derive(dz, dh, &s.v[0], &s.y, l.Activation.or(LeakyReLU), l.Slope)
+		gradOf(grads[0], &dx[i], &s.x, &l.Wx, dz)
+		sumRows(grads[2].Data, dz, nil)
+
+		// Carry the gradient of the previous state
+		dh.Reset(dz.Rows, dz.Cols)
+		if l.dense {
+			gradOf(grads[1], dh, h, &l.Wh, dz)
+		} else {
+			sumRows(grads[1].Data, dz, h)
+			copy(dh.Data, dz.Data)
+			mulRows(dh, l.Wh.Data)
+		}
+	}
+	return dx
+}
+
+// Crossover performs crossover between two genomes
+func (l *RNN) Crossover(l1, l2 *RNN) {
 	crossoverMatrix(&l.Wx, &l1.Wx, &l2.Wx)
 	crossoverMatrix(&l.Wh, &l1.Wh, &l2.Wh)
 	crossoverMatrix(&l.Bh, &l1.Bh, &l2.Bh)
 }
 
+// Weights returns the weight matrices of the layer, in the order they are serialized
+func (l *RNN) Weights() []*math32.Matrix {
+	return []*math32.Matrix{&l.Wx, &l.Wh, &l.Bh}
+}
+
 // Mutate mutates the genome
 func (l *RNN) Mutate() {
 	const rate = 0.05
@@ -61,6 +142,15 @@ func (l *RNN) Mutate() {
 	mutateBias(l.Bh.Data, rate)
 }
 
-func (l *RNN) Reset() {
-	l.h.Zero()
+// Clone returns a copy of the layer
+func (l *RNN) Clone() *RNN {
+	return &RNN{
+		Wx: l.Wx.Clone(),
+		Wh: l.Wh.Clone(),
+		Bh: l.Bh.Clone(),
+
+		dense:      l.dense,
+		Activation: l.Activation,
+		Slope:      l.Slope,
+	}
 }
@@ -15,10 +15,10 @@ func TestRNNUpdate(t *testing.T) {
 		}),
 		Wh: math32.NewMatrix(1, 2, []float32{0.5, 0.25}),
 		Bh: math32.NewMatrix(1, 2, []float32{0, 0}),
-		h:  math32.NewMatrix(1, 2, nil),
 	}
 
 	x := math32.NewMatrix(1, 2, []float32{1, 2})
+	var s State
 	var dst math32.Matrix
 
 	// first step
@@ -28,11 +28,11 @@ func TestRNNUpdate(t *testing.T) {
 	math32.Add(exp1.Data, r.Bh.Data)
 	math32.Lrelu(exp1.Data)
 
-	out1 := r.Update(&dst, &x)
+	out1 := r.Forward(&dst, &x, &s)
 	assert.InDeltaSlice(t, exp1.Data, out1.Data, 1e-5)
 
 	// second step with same input
-	prev := append([]float32(nil), r.h.Data...)
+	prev := append([]float32(nil), s.h.Data...)
 	exp2 := math32.NewMatrix(1, 2, nil)
 	math32.Matmul(&exp2, &x, &r.Wx)
 	for i := range prev {
@@ -42,6 +42,26 @@ func TestRNNUpdate(t *testing.T) {
 	math32.Add(exp2.Data, r.Bh.Data)
 	math32.Lrelu(exp2.Data)
 
-	out2 := r.Update(&dst, &x)
+	out2 := r.Forward(&dst, &x, &s)
 	assert.InDeltaSlice(t, exp2.Data, out2.Data, 1e-5)
 }
+
+func TestRNNDense(t *testing.T) {
+	r := NewDenseRNN(3, 4)
+	assert.True(t, r.Dense())
+	assert.Equal(t, 4, r.Wh.Rows)
+	assert.False(t, NewRNN(3, 4).Dense())
+
+	x := math32.NewMatrix(1, 3, []float32{1, -0.5, 2})
+	h := make([]float32, 4)
+
+	var s State
+	var dst math32.Matrix
+	for step := 0; step < 3; step++ {
+		h = gateOf(&r.Wx, &r.Wh, &r.Bh, x.Data, h)
+		math32.LeakyRelu(h, 0.01)
+		assert.InDeltaSlice(t, h, r.Forward(&dst, &x, &s).Data, 1e-5)
+	}
+
+	assert.True(t, r.Clone().Dense())
+}
@@ -0,0 +1,53 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package layer
+
+import (
+	"github.com/kelindar/evolve/neural/math32"
+)
+
+// State represents the recurrent state of a layer for a single session, along with the scratch
+// space needed to update it. Since a layer only reads its weights when it updates a separate
+// state, a single layer can serve many sessions concurrently, each with its own state.
+type State struct {
+	h, c math32.Matrix    // The hidden state and, for the LSTM, the cell state
+	tmp  [4]math32.Matrix // The scratch space
+}
+
+// Reset clears the recurrent state
+func (s *State) Reset() {
+	s.h.Zero()
+	s.c.Zero()
+}
+
+// resize resizes the matrix to the number of rows of the input, where every row holds the
+// state of an independent sequence. The matrix is cleared if the batch size changes.
+func resize(m *math32.Matrix, rows, cols int) *math32.Matrix {
+	if m.Rows != rows || m.Cols != cols {
+		m.Reset(rows, cols)
+	}
+	return m
+}
+
+// gate computes W·x + U·h + b into the destination matrix
+func gate(dst, x, h, w, u, b *math32.Matrix) {
+	dst.Reset(x.Rows, w.Cols)
+	math32.Matmul(dst, x, w)
+	math32.Matmul(dst, h, u)
+	addRows(dst, b.Data)
+}
+
+// addRows adds the vector to every row of the matrix
+func addRows(dst *math32.Matrix, v []float32) {
+	for i := 0; i < len(dst.Data); i += dst.Cols {
+		math32.Add(dst.Data[i:i+dst.Cols], v)
+	}
+}
+
+// mulRows multiplies every row of the matrix element-wise with the vector
+func mulRows(dst *math32.Matrix, v []float32) {
+	for i := 0; i < len(dst.Data); i += dst.Cols {
+		math32.Mul(dst.Data[i:i+dst.Cols], v)
+	}
+}
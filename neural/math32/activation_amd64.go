@@ -0,0 +1,30 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package math32
+
+import "unsafe"
+
+//go:noescape,nosplit
+func _f32_relu(x unsafe.Pointer, n uint64)
+
+//go:noescape,nosplit
+func _f32_lrelu(x unsafe.Pointer, n uint64, slope unsafe.Pointer)
+
+//go:noescape,nosplit
+func _f32_exp(x unsafe.Pointer, n uint64)
+
+//go:noescape,nosplit
+func _f32_sigmoid(x unsafe.Pointer, n uint64)
+
+//go:noescape,nosplit
+func _f32_tanh(x unsafe.Pointer, n uint64)
+
+//go:noescape,nosplit
+func _f32_swish(x unsafe.Pointer, n uint64)
+
+//go:noescape,nosplit
+func _f32_gelu(x unsafe.Pointer, n uint64)
+
+//go:noescape,nosplit
+func _f32_softplus(x unsafe.Pointer, n uint64)
@@ -0,0 +1,577 @@
+//+build !noasm !appengine
+// AUTO-GENERATED BY C2GOASM -- DO NOT EDIT
+
+TEXT ·_f32_relu(SB), $0-16
+
+    MOVQ x+0(FP), DI
+    MOVQ n+8(FP), SI
+
+    LONG $0x08fe8348             // cmp    rsi, 8
+	JB LBB0_6
+    LONG $0xf8c68348             // add    rsi, -8
+    WORD $0x8948; BYTE $0xf1     // mov    rcx, rsi
+    LONG $0x03e9c148             // shr    rcx, 3
+    LONG $0x01c18348             // add    rcx, 1
+    WORD $0xc889                 // mov    eax, ecx
+    WORD $0xe083; BYTE $0x03     // and    eax, 3
+    LONG $0x18fe8348             // cmp    rsi, 24
+	JAE LBB0_7
+    WORD $0xd231                 // xor    edx, edx
+	JMP LBB0_3
+LBB0_7:
+    LONG $0xfce18348             // and    rcx, -4
+    WORD $0xd231                 // xor    edx, edx
+    LONG $0xc057f8c5             // vxorps    xmm0, xmm0, xmm0
+LBB0_8:
+    LONG $0x0c5ffcc5; BYTE $0x97 // vmaxps    ymm1, ymm0, yword [rdi + 4*rdx]
+    LONG $0x0c11fcc5; BYTE $0x97 // vmovups    yword [rdi + 4*rdx], ymm1
+    LONG $0x4c5ffcc5; WORD $0x2097 // vmaxps    ymm1, ymm0, yword [rdi + 4*rdx + 32]
+    LONG $0x4c11fcc5; WORD $0x2097 // vmovups    yword [rdi + 4*rdx + 32], ymm1
+    LONG $0x4c5ffcc5; WORD $0x4097 // vmaxps    ymm1, ymm0, yword [rdi + 4*rdx + 64]
+    LONG $0x4c11fcc5; WORD $0x4097 // vmovups    yword [rdi + 4*rdx + 64], ymm1
+    LONG $0x4c5ffcc5; WORD $0x6097 // vmaxps    ymm1, ymm0, yword [rdi + 4*rdx + 96]
+    LONG $0x4c11fcc5; WORD $0x6097 // vmovups    yword [rdi + 4*rdx + 96], ymm1
+    LONG $0x20c28348             // add    rdx, 32
+    LONG $0xfcc18348             // add    rcx, -4
+	JNE LBB0_8
+LBB0_3:
+    WORD $0x8548; BYTE $0xc0     // test    rax, rax
+	JE LBB0_6
+    LONG $0x970c8d48             // lea    rcx, [rdi + 4*rdx]
+    LONG $0x05e0c148             // shl    rax, 5
+    WORD $0xd231                 // xor    edx, edx
+    LONG $0xc057f8c5             // vxorps    xmm0, xmm0, xmm0
+LBB0_5:
+    LONG $0x0c5ffcc5; BYTE $0x11 // vmaxps    ymm1, ymm0, yword [rcx + rdx]
+    LONG $0x0c11fcc5; BYTE $0x11 // vmovups    yword [rcx + rdx], ymm1
+    LONG $0x20c28348             // add    rdx, 32
+    WORD $0x3948; BYTE $0xd0     // cmp    rax, rdx
+	JNE LBB0_5
+LBB0_6:
+    VZEROUPPER
+    RET
+
+
+
+
+DATA LCDATA1<>+0x000(SB)/8, $0x000000003f800000
+GLOBL LCDATA1<>(SB), 8, $8
+
+TEXT ·_f32_lrelu(SB), $8-24
+
+    MOVQ x+0(FP), DI
+    MOVQ n+8(FP), SI
+    MOVQ slope+16(FP), DX
+    LEAQ LCDATA1<>(SB), BP
+
+    LONG $0x08fe8348             // cmp    rsi, 8
+	JB LBB1_5
+    LONG $0x187de2c4; BYTE $0x02 // vbroadcastss    ymm0, dword [rdx]
+    LONG $0xf8c68348             // add    rsi, -8
+    WORD $0x8948; BYTE $0xf0     // mov    rax, rsi
+    LONG $0x03e8c148             // shr    rax, 3
+    LONG $0x01c08348             // add    rax, 1
+    LONG $0x08fe8348             // cmp    rsi, 8
+	JAE LBB1_6
+    WORD $0xc931                 // xor    ecx, ecx
+	JMP LBB1_3
+LBB1_6:
+    WORD $0x8948; BYTE $0xc2     // mov    rdx, rax
+    LONG $0xfee28348             // and    rdx, -2
+    WORD $0xc931                 // xor    ecx, ecx
+    LONG $0xc957f0c5             // vxorps    xmm1, xmm1, xmm1
+    LONG $0x187de2c4; WORD $0x0055 // vbroadcastss    ymm2, dword 0[rbp] /* [rip + .LCPI1_0] */
+LBB1_7:
+    LONG $0x1c10fcc5; BYTE $0x8f // vmovups    ymm3, yword [rdi + 4*rcx]
+    LONG $0x6410fcc5; WORD $0x208f // vmovups    ymm4, yword [rdi + 4*rcx + 32]
+    LONG $0xe9c2e4c5; BYTE $0x01 // vcmpltps    ymm5, ymm3, ymm1
+    LONG $0x4a6de3c4; WORD $0x50e8 // vblendvps    ymm5, ymm2, ymm0, ymm5
+    LONG $0xdb59d4c5             // vmulps    ymm3, ymm5, ymm3
+    LONG $0x1c11fcc5; BYTE $0x8f // vmovups    yword [rdi + 4*rcx], ymm3
+    LONG $0xd9c2dcc5; BYTE $0x01 // vcmpltps    ymm3, ymm4, ymm1
+    LONG $0x4a6de3c4; WORD $0x30d8 // vblendvps    ymm3, ymm2, ymm0, ymm3
+    LONG $0xdc59e4c5             // vmulps    ymm3, ymm3, ymm4
+    LONG $0x5c11fcc5; WORD $0x208f // vmovups    yword [rdi + 4*rcx + 32], ymm3
+    LONG $0x10c18348             // add    rcx, 16
+    LONG $0xfec28348             // add    rdx, -2
+	JNE LBB1_7
+LBB1_3:
+    WORD $0x01a8                 // test    al, 1
+	JE LBB1_5
+    LONG $0x0c10fcc5; BYTE $0x8f // vmovups    ymm1, yword [rdi + 4*rcx]
+    LONG $0xd257e8c5             // vxorps    xmm2, xmm2, xmm2
+    LONG $0xd2c2f4c5; BYTE $0x01 // vcmpltps    ymm2, ymm1, ymm2
+    LONG $0x187de2c4; WORD $0x005d // vbroadcastss    ymm3, dword 0[rbp] /* [rip + .LCPI1_0] */
+    LONG $0x4a65e3c4; WORD $0x20c0 // vblendvps    ymm0, ymm3, ymm0, ymm2
+    LONG $0xc159fcc5             // vmulps    ymm0, ymm0, ymm1
+    LONG $0x0411fcc5; BYTE $0x8f // vmovups    yword [rdi + 4*rcx], ymm0
+LBB1_5:
+    VZEROUPPER
+    RET
+
+
+
+
+DATA LCDATA2<>+0x000(SB)/8, $0xc2ae000042b00000
+DATA LCDATA2<>+0x008(SB)/8, $0x3f3180003fb8aa3b
+DATA LCDATA2<>+0x010(SB)/8, $0x3ab743ce395e8083
+DATA LCDATA2<>+0x018(SB)/8, $0x3c08890839506967
+DATA LCDATA2<>+0x020(SB)/8, $0x3e2aaaaa3d2aa9c1
+DATA LCDATA2<>+0x028(SB)/8, $0x3f8000003f000000
+GLOBL LCDATA2<>(SB), 8, $48
+
+TEXT ·_f32_exp(SB), $8-16
+
+    MOVQ x+0(FP), DI
+    MOVQ n+8(FP), SI
+    LEAQ LCDATA2<>(SB), BP
+
+    LONG $0x08fe8348             // cmp    rsi, 8
+	JB LBB2_3
+    LONG $0x000007b8; BYTE $0x00 // mov    eax, 7
+    LONG $0x187de2c4; WORD $0x0045 // vbroadcastss    ymm0, dword 0[rbp] /* [rip + .LCPI2_0] */
+    LONG $0x187de2c4; WORD $0x044d // vbroadcastss    ymm1, dword 4[rbp] /* [rip + .LCPI2_1] */
+    LONG $0x187de2c4; WORD $0x0855 // vbroadcastss    ymm2, dword 8[rbp] /* [rip + .LCPI2_2] */
+    LONG $0x187de2c4; WORD $0x0c5d // vbroadcastss    ymm3, dword 12[rbp] /* [rip + .LCPI2_3] */
+    LONG $0x187de2c4; WORD $0x1065 // vbroadcastss    ymm4, dword 16[rbp] /* [rip + .LCPI2_4] */
+    LONG $0x187de2c4; WORD $0x146d // vbroadcastss    ymm5, dword 20[rbp] /* [rip + .LCPI2_5] */
+    LONG $0x187de2c4; WORD $0x1875 // vbroadcastss    ymm6, dword 24[rbp] /* [rip + .LCPI2_6] */
+    LONG $0x187de2c4; WORD $0x1c7d // vbroadcastss    ymm7, dword 28[rbp] /* [rip + .LCPI2_7] */
+    LONG $0x187d62c4; WORD $0x2045 // vbroadcastss    ymm8, dword 32[rbp] /* [rip + .LCPI2_8] */
+    LONG $0x187d62c4; WORD $0x244d // vbroadcastss    ymm9, dword 36[rbp] /* [rip + .LCPI2_9] */
+    LONG $0x187d62c4; WORD $0x2855 // vbroadcastss    ymm10, dword 40[rbp] /* [rip + .LCPI2_10] */
+    LONG $0x587d62c4; WORD $0x2c5d // vpbroadcastd    ymm11, dword 44[rbp] /* [rip + .LCPI2_11] */
+LBB2_2:
+    LONG $0x645d7cc5; WORD $0xe487 // vminps    ymm12, ymm0, yword [rdi + 4*rax - 28]
+    LONG $0xe15f1cc5             // vmaxps    ymm12, ymm12, ymm1
+    LONG $0xea591cc5             // vmulps    ymm13, ymm12, ymm2
+    LONG $0x087d43c4; WORD $0x08ed // vroundps    ymm13, ymm13, 8
+    LONG $0xba1562c4; BYTE $0xe3 // vfmsub231ps    ymm12, ymm13, ymm3
+    LONG $0xba1562c4; BYTE $0xe4 // vfmsub231ps    ymm12, ymm13, ymm4
+    LONG $0xf6287cc5             // vmovaps    ymm14, ymm6
+    LONG $0xa81d62c4; BYTE $0xf5 // vfmadd213ps    ymm14, ymm12, ymm5
+    LONG $0xa81d62c4; BYTE $0xf7 // vfmadd213ps    ymm14, ymm12, ymm7
+    LONG $0xa81d42c4; BYTE $0xf0 // vfmadd213ps    ymm14, ymm12, ymm8
+    LONG $0xa81d42c4; BYTE $0xf1 // vfmadd213ps    ymm14, ymm12, ymm9
+    LONG $0xa81d42c4; BYTE $0xf2 // vfmadd213ps    ymm14, ymm12, ymm10
+    LONG $0x591c41c4; BYTE $0xfc // vmulps    ymm15, ymm12, ymm12
+    LONG $0xa80d42c4; BYTE $0xfc // vfmadd213ps    ymm15, ymm14, ymm12
+    LONG $0x5b7d41c4; BYTE $0xe5 // vcvtps2dq    ymm12, ymm13
+    LONG $0x721dc1c4; WORD $0x17f4 // vpslld    ymm12, ymm12, 23
+    LONG $0xfe1d41c4; BYTE $0xe3 // vpaddd    ymm12, ymm12, ymm11
+    LONG $0xa80542c4; BYTE $0xe4 // vfmadd213ps    ymm12, ymm15, ymm12
+    LONG $0x64117cc5; WORD $0xe487 // vmovups    yword [rdi + 4*rax - 28], ymm12
+    LONG $0x08c08348             // add    rax, 8
+    WORD $0x3948; BYTE $0xf0     // cmp    rax, rsi
+	JB LBB2_2
+LBB2_3:
+    VZEROUPPER
+    RET
+
+
+
+
+DATA LCDATA3<>+0x000(SB)/8, $0x42b0000080000000
+DATA LCDATA3<>+0x008(SB)/8, $0x3fb8aa3bc2ae0000
+DATA LCDATA3<>+0x010(SB)/8, $0x395e80833f318000
+DATA LCDATA3<>+0x018(SB)/8, $0x395069673ab743ce
+DATA LCDATA3<>+0x020(SB)/8, $0x3d2aa9c13c088908
+DATA LCDATA3<>+0x028(SB)/8, $0x3f0000003e2aaaaa
+DATA LCDATA3<>+0x030(SB)/8, $0x000000003f800000
+GLOBL LCDATA3<>(SB), 8, $56
+
+TEXT ·_f32_sigmoid(SB), $104-16
+
+    MOVQ x+0(FP), DI
+    MOVQ n+8(FP), SI
+    ADDQ $8, SP
+    LEAQ LCDATA3<>(SB), BP
+
+    LONG $0x08fe8348             // cmp    rsi, 8
+	JB LBB3_3
+    LONG $0x000007b8; BYTE $0x00 // mov    eax, 7
+    LONG $0x187de2c4; WORD $0x0045 // vbroadcastss    ymm0, dword 0[rbp] /* [rip + .LCPI3_0] */
+    LONG $0x4411fcc5; WORD $0x2024 // vmovups    yword [rsp + 32], ymm0
+    LONG $0x187de2c4; WORD $0x0445 // vbroadcastss    ymm0, dword 4[rbp] /* [rip + .LCPI3_1] */
+    LONG $0x0411fcc5; BYTE $0x24 // vmovups    yword [rsp], ymm0
+    LONG $0x187de2c4; WORD $0x0855 // vbroadcastss    ymm2, dword 8[rbp] /* [rip + .LCPI3_2] */
+    LONG $0x187de2c4; WORD $0x0c5d // vbroadcastss    ymm3, dword 12[rbp] /* [rip + .LCPI3_3] */
+    LONG $0x187de2c4; WORD $0x1065 // vbroadcastss    ymm4, dword 16[rbp] /* [rip + .LCPI3_4] */
+    LONG $0x187de2c4; WORD $0x146d // vbroadcastss    ymm5, dword 20[rbp] /* [rip + .LCPI3_5] */
+    LONG $0x187de2c4; WORD $0x1875 // vbroadcastss    ymm6, dword 24[rbp] /* [rip + .LCPI3_6] */
+    LONG $0x187de2c4; WORD $0x1c45 // vbroadcastss    ymm0, dword 28[rbp] /* [rip + .LCPI3_7] */
+    LONG $0x187d62c4; WORD $0x2045 // vbroadcastss    ymm8, dword 32[rbp] /* [rip + .LCPI3_8] */
+    LONG $0x187d62c4; WORD $0x244d // vbroadcastss    ymm9, dword 36[rbp] /* [rip + .LCPI3_9] */
+    LONG $0x187d62c4; WORD $0x2855 // vbroadcastss    ymm10, dword 40[rbp] /* [rip + .LCPI3_10] */
+    LONG $0x187d62c4; WORD $0x2c5d // vbroadcastss    ymm11, dword 44[rbp] /* [rip + .LCPI3_11] */
+    LONG $0x187d62c4; WORD $0x3065 // vbroadcastss    ymm12, dword 48[rbp] /* [rip + .LCPI3_12] */
+    LONG $0x587d62c4; WORD $0x306d // vpbroadcastd    ymm13, dword 48[rbp] /* [rip + .LCPI3_12] */
+LBB3_2:
+    LONG $0x4c10fcc5; WORD $0x2024 // vmovups    ymm1, yword [rsp + 32]
+    LONG $0x745774c5; WORD $0xe487 // vxorps    ymm14, ymm1, yword [rdi + 4*rax - 28]
+    LONG $0x345d0cc5; BYTE $0x24 // vminps    ymm14, ymm14, yword [rsp]
+    LONG $0xf25f0cc5             // vmaxps    ymm14, ymm14, ymm2
+    LONG $0xfb590cc5             // vmulps    ymm15, ymm14, ymm3
+    LONG $0x087d43c4; WORD $0x08ff // vroundps    ymm15, ymm15, 8
+    LONG $0xba0562c4; BYTE $0xf4 // vfmsub231ps    ymm14, ymm15, ymm4
+    LONG $0xba0562c4; BYTE $0xf5 // vfmsub231ps    ymm14, ymm15, ymm5
+    LONG $0xf828fcc5             // vmovaps    ymm7, ymm0
+    LONG $0xa80de2c4; BYTE $0xfe // vfmadd213ps    ymm7, ymm14, ymm6
+    LONG $0xa80dc2c4; BYTE $0xf8 // vfmadd213ps    ymm7, ymm14, ymm8
+    LONG $0xa80dc2c4; BYTE $0xf9 // vfmadd213ps    ymm7, ymm14, ymm9
+    LONG $0xa80dc2c4; BYTE $0xfa // vfmadd213ps    ymm7, ymm14, ymm10
+    LONG $0xa80dc2c4; BYTE $0xfb // vfmadd213ps    ymm7, ymm14, ymm11
+    LONG $0x590cc1c4; BYTE $0xce // vmulps    ymm1, ymm14, ymm14
+    LONG $0xa845c2c4; BYTE $0xce // vfmadd213ps    ymm1, ymm7, ymm14
+    LONG $0xc9589cc5             // vaddps    ymm1, ymm12, ymm1
+    LONG $0x5b7dc1c4; BYTE $0xff // vcvtps2dq    ymm7, ymm15
+    LONG $0xf772c5c5; BYTE $0x17 // vpslld    ymm7, ymm7, 23
+    LONG $0xfffe95c5             // vpaddd    ymm7, ymm13, ymm7
+    LONG $0xa875c2c4; BYTE $0xfc // vfmadd213ps    ymm7, ymm1, ymm12
+    LONG $0xcf53fcc5             // vrcpps    ymm1, ymm7
+    LONG $0xaa75c2c4; BYTE $0xfc // vfmsub213ps    ymm7, ymm1, ymm12
+    LONG $0x9c75e2c4; BYTE $0xf9 // vfnmadd132ps    ymm7, ymm1, ymm1
+    LONG $0x7c11fcc5; WORD $0xe487 // vmovups    yword [rdi + 4*rax - 28], ymm7
+    LONG $0x08c08348             // add    rax, 8
+    WORD $0x3948; BYTE $0xf0     // cmp    rax, rsi
+	JB LBB3_2
+LBB3_3:
+    SUBQ $8, SP
+    VZEROUPPER
+    RET
+
+
+
+
+DATA LCDATA4<>+0x000(SB)/8, $0x42b0000040000000
+DATA LCDATA4<>+0x008(SB)/8, $0x3fb8aa3bc2ae0000
+DATA LCDATA4<>+0x010(SB)/8, $0x395e80833f318000
+DATA LCDATA4<>+0x018(SB)/8, $0x395069673ab743ce
+DATA LCDATA4<>+0x020(SB)/8, $0x3d2aa9c13c088908
+DATA LCDATA4<>+0x028(SB)/8, $0x3f0000003e2aaaaa
+DATA LCDATA4<>+0x030(SB)/8, $0xbf8000003f800000
+DATA LCDATA4<>+0x038(SB)/8, $0x0000000000000000
+DATA LCDATA4<>+0x040(SB)/8, $0x0000000000000000
+DATA LCDATA4<>+0x048(SB)/8, $0x0000000000000000
+DATA LCDATA4<>+0x050(SB)/8, $0x0000000000000000
+DATA LCDATA4<>+0x058(SB)/8, $0x0000000000000000
+GLOBL LCDATA4<>(SB), 8, $96
+
+TEXT ·_f32_tanh(SB), $136-16
+
+    MOVQ x+0(FP), DI
+    MOVQ n+8(FP), SI
+    ADDQ $8, SP
+    LEAQ LCDATA4<>(SB), BP
+
+    LONG $0x08fe8348             // cmp    rsi, 8
+	JB LBB4_3
+    LONG $0x000007b8; BYTE $0x00 // mov    eax, 7
+    LONG $0x187de2c4; WORD $0x0045 // vbroadcastss    ymm0, dword 0[rbp] /* [rip + .LCPI4_0] */
+    LONG $0x187de2c4; WORD $0x044d // vbroadcastss    ymm1, dword 4[rbp] /* [rip + .LCPI4_1] */
+    LONG $0x4c11fcc5; WORD $0x4024 // vmovups    yword [rsp + 64], ymm1
+    LONG $0x187de2c4; WORD $0x084d // vbroadcastss    ymm1, dword 8[rbp] /* [rip + .LCPI4_2] */
+    LONG $0x4c11fcc5; WORD $0x2024 // vmovups    yword [rsp + 32], ymm1
+    LONG $0x187de2c4; WORD $0x0c4d // vbroadcastss    ymm1, dword 12[rbp] /* [rip + .LCPI4_3] */
+    LONG $0x0c11fcc5; BYTE $0x24 // vmovups    yword [rsp], ymm1
+    LONG $0x187de2c4; WORD $0x106d // vbroadcastss    ymm5, dword 16[rbp] /* [rip + .LCPI4_4] */
+    LONG $0x187de2c4; WORD $0x1475 // vbroadcastss    ymm6, dword 20[rbp] /* [rip + .LCPI4_5] */
+    LONG $0x187de2c4; WORD $0x187d // vbroadcastss    ymm7, dword 24[rbp] /* [rip + .LCPI4_6] */
+    LONG $0x187de2c4; WORD $0x1c5d // vbroadcastss    ymm3, dword 28[rbp] /* [rip + .LCPI4_7] */
+    LONG $0x187d62c4; WORD $0x204d // vbroadcastss    ymm9, dword 32[rbp] /* [rip + .LCPI4_8] */
+    LONG $0x187d62c4; WORD $0x2455 // vbroadcastss    ymm10, dword 36[rbp] /* [rip + .LCPI4_9] */
+    LONG $0x187d62c4; WORD $0x285d // vbroadcastss    ymm11, dword 40[rbp] /* [rip + .LCPI4_10] */
+    LONG $0x187d62c4; WORD $0x2c65 // vbroadcastss    ymm12, dword 44[rbp] /* [rip + .LCPI4_11] */
+    LONG $0x187d62c4; WORD $0x306d // vbroadcastss    ymm13, dword 48[rbp] /* [rip + .LCPI4_12] */
+    LONG $0x587d62c4; WORD $0x3075 // vpbroadcastd    ymm14, dword 48[rbp] /* [rip + .LCPI4_12] */
+    LONG $0x187d62c4; WORD $0x347d // vbroadcastss    ymm15, dword 52[rbp] /* [rip + .LCPI4_13] */
+LBB4_2:
+    LONG $0x4c10fcc5; WORD $0xe487 // vmovups    ymm1, yword [rdi + 4*rax - 28]
+    LONG $0xae7de2c4; WORD $0x404d // vfnmsub213ps    ymm1, ymm0, yword 64[rbp] /* [rip + .LCPI4_14] */
+    LONG $0x4c5df4c5; WORD $0x4024 // vminps    ymm1, ymm1, yword [rsp + 64]
+    LONG $0x4c5ff4c5; WORD $0x2024 // vmaxps    ymm1, ymm1, yword [rsp + 32]
+    LONG $0x1459f4c5; BYTE $0x24 // vmulps    ymm2, ymm1, yword [rsp]
+    LONG $0x087de3c4; WORD $0x08d2 // vroundps    ymm2, ymm2, 8
+    LONG $0xba6de2c4; BYTE $0xcd // vfmsub231ps    ymm1, ymm2, ymm5
+    LONG $0xba6de2c4; BYTE $0xce // vfmsub231ps    ymm1, ymm2, ymm6
+    LONG $0xc3287cc5             // vmovaps    ymm8, ymm3
+    LONG $0xa87562c4; BYTE $0xc7 // vfmadd213ps    ymm8, ymm1, ymm7
+    LONG $0xa87542c4; BYTE $0xc1 // vfmadd213ps    ymm8, ymm1, ymm9
+    LONG $0xa87542c4; BYTE $0xc2 // vfmadd213ps    ymm8, ymm1, ymm10
+    LONG $0xa87542c4; BYTE $0xc3 // vfmadd213ps    ymm8, ymm1, ymm11
+    LONG $0xa87542c4; BYTE $0xc4 // vfmadd213ps    ymm8, ymm1, ymm12
+    LONG $0xe159f4c5             // vmulps    ymm4, ymm1, ymm1
+    LONG $0xa83de2c4; BYTE $0xe1 // vfmadd213ps    ymm4, ymm8, ymm1
+    LONG $0xcc5894c5             // vaddps    ymm1, ymm13, ymm4
+    LONG $0xd25bfdc5             // vcvtps2dq    ymm2, ymm2
+    LONG $0xf272edc5; BYTE $0x17 // vpslld    ymm2, ymm2, 23
+    LONG $0xd2fe8dc5             // vpaddd    ymm2, ymm14, ymm2
+    LONG $0xa875c2c4; BYTE $0xd5 // vfmadd213ps    ymm2, ymm1, ymm13
+    LONG $0xca53fcc5             // vrcpps    ymm1, ymm2
+    LONG $0xe158f4c5             // vaddps    ymm4, ymm1, ymm1
+    LONG $0xaa5de2c4; BYTE $0xd0 // vfmsub213ps    ymm2, ymm4, ymm0
+    LONG $0xac75e2c4; BYTE $0xd4 // vfnmadd213ps    ymm2, ymm1, ymm4
+    LONG $0xca5884c5             // vaddps    ymm1, ymm15, ymm2
+    LONG $0x4c11fcc5; WORD $0xe487 // vmovups    yword [rdi + 4*rax - 28], ymm1
+    LONG $0x08c08348             // add    rax, 8
+    WORD $0x3948; BYTE $0xf0     // cmp    rax, rsi
+	JB LBB4_2
+LBB4_3:
+    SUBQ $8, SP
+    VZEROUPPER
+    RET
+
+
+
+
+DATA LCDATA5<>+0x000(SB)/8, $0x42b0000080000000
+DATA LCDATA5<>+0x008(SB)/8, $0x3fb8aa3bc2ae0000
+DATA LCDATA5<>+0x010(SB)/8, $0x395e80833f318000
+DATA LCDATA5<>+0x018(SB)/8, $0x395069673ab743ce
+DATA LCDATA5<>+0x020(SB)/8, $0x3d2aa9c13c088908
+DATA LCDATA5<>+0x028(SB)/8, $0x3f0000003e2aaaaa
+DATA LCDATA5<>+0x030(SB)/8, $0x000000003f800000
+GLOBL LCDATA5<>(SB), 8, $56
+
+TEXT ·_f32_swish(SB), $136-16
+
+    MOVQ x+0(FP), DI
+    MOVQ n+8(FP), SI
+    ADDQ $8, SP
+    LEAQ LCDATA5<>(SB), BP
+
+    LONG $0x08fe8348             // cmp    rsi, 8
+	JB LBB5_3
+    LONG $0x000007b8; BYTE $0x00 // mov    eax, 7
+    LONG $0x187de2c4; WORD $0x0045 // vbroadcastss    ymm0, dword 0[rbp] /* [rip + .LCPI5_0] */
+    LONG $0x4411fcc5; WORD $0x4024 // vmovups    yword [rsp + 64], ymm0
+    LONG $0x187de2c4; WORD $0x0445 // vbroadcastss    ymm0, dword 4[rbp] /* [rip + .LCPI5_1] */
+    LONG $0x4411fcc5; WORD $0x2024 // vmovups    yword [rsp + 32], ymm0
+    LONG $0x187de2c4; WORD $0x0845 // vbroadcastss    ymm0, dword 8[rbp] /* [rip + .LCPI5_2] */
+    LONG $0x0411fcc5; BYTE $0x24 // vmovups    yword [rsp], ymm0
+    LONG $0x187de2c4; WORD $0x0c5d // vbroadcastss    ymm3, dword 12[rbp] /* [rip + .LCPI5_3] */
+    LONG $0x187de2c4; WORD $0x1065 // vbroadcastss    ymm4, dword 16[rbp] /* [rip + .LCPI5_4] */
+    LONG $0x187de2c4; WORD $0x146d // vbroadcastss    ymm5, dword 20[rbp] /* [rip + .LCPI5_5] */
+    LONG $0x187de2c4; WORD $0x1875 // vbroadcastss    ymm6, dword 24[rbp] /* [rip + .LCPI5_6] */
+    LONG $0x187de2c4; WORD $0x1c4d // vbroadcastss    ymm1, dword 28[rbp] /* [rip + .LCPI5_7] */
+    LONG $0x187d62c4; WORD $0x2045 // vbroadcastss    ymm8, dword 32[rbp] /* [rip + .LCPI5_8] */
+    LONG $0x187d62c4; WORD $0x244d // vbroadcastss    ymm9, dword 36[rbp] /* [rip + .LCPI5_9] */
+    LONG $0x187d62c4; WORD $0x2855 // vbroadcastss    ymm10, dword 40[rbp] /* [rip + .LCPI5_10] */
+    LONG $0x187d62c4; WORD $0x2c5d // vbroadcastss    ymm11, dword 44[rbp] /* [rip + .LCPI5_11] */
+    LONG $0x187d62c4; WORD $0x3065 // vbroadcastss    ymm12, dword 48[rbp] /* [rip + .LCPI5_12] */
+    LONG $0x587d62c4; WORD $0x306d // vpbroadcastd    ymm13, dword 48[rbp] /* [rip + .LCPI5_12] */
+LBB5_2:
+    LONG $0x74107cc5; WORD $0xe487 // vmovups    ymm14, yword [rdi + 4*rax - 28]
+    LONG $0x7c570cc5; WORD $0x4024 // vxorps    ymm15, ymm14, yword [rsp + 64]
+    LONG $0x7c5d04c5; WORD $0x2024 // vminps    ymm15, ymm15, yword [rsp + 32]
+    LONG $0x3c5f04c5; BYTE $0x24 // vmaxps    ymm15, ymm15, yword [rsp]
+    LONG $0xc35984c5             // vmulps    ymm0, ymm15, ymm3
+    LONG $0x087de3c4; WORD $0x08c0 // vroundps    ymm0, ymm0, 8
+    LONG $0xba7d62c4; BYTE $0xfc // vfmsub231ps    ymm15, ymm0, ymm4
+    LONG $0xba7d62c4; BYTE $0xfd // vfmsub231ps    ymm15, ymm0, ymm5
+    LONG $0xf928fcc5             // vmovaps    ymm7, ymm1
+    LONG $0xa805e2c4; BYTE $0xfe // vfmadd213ps    ymm7, ymm15, ymm6
+    LONG $0xa805c2c4; BYTE $0xf8 // vfmadd213ps    ymm7, ymm15, ymm8
+    LONG $0xa805c2c4; BYTE $0xf9 // vfmadd213ps    ymm7, ymm15, ymm9
+    LONG $0xa805c2c4; BYTE $0xfa // vfmadd213ps    ymm7, ymm15, ymm10
+    LONG $0xa805c2c4; BYTE $0xfb // vfmadd213ps    ymm7, ymm15, ymm11
+    LONG $0x5904c1c4; BYTE $0xd7 // vmulps    ymm2, ymm15, ymm15
+    LONG $0xa845c2c4; BYTE $0xd7 // vfmadd213ps    ymm2, ymm7, ymm15
+    LONG $0xd2589cc5             // vaddps    ymm2, ymm12, ymm2
+    LONG $0xc05bfdc5             // vcvtps2dq    ymm0, ymm0
+    LONG $0xf072fdc5; BYTE $0x17 // vpslld    ymm0, ymm0, 23
+    LONG $0xc0fe95c5             // vpaddd    ymm0, ymm13, ymm0
+    LONG $0xa86dc2c4; BYTE $0xc4 // vfmadd213ps    ymm0, ymm2, ymm12
+    LONG $0xd053fcc5             // vrcpps    ymm2, ymm0
+    LONG $0xfa598cc5             // vmulps    ymm7, ymm14, ymm2
+    LONG $0xaa45c2c4; BYTE $0xc6 // vfmsub213ps    ymm0, ymm7, ymm14
+    LONG $0xac6de2c4; BYTE $0xc7 // vfnmadd213ps    ymm0, ymm2, ymm7
+    LONG $0x4411fcc5; WORD $0xe487 // vmovups    yword [rdi + 4*rax - 28], ymm0
+    LONG $0x08c08348             // add    rax, 8
+    WORD $0x3948; BYTE $0xf0     // cmp    rax, rsi
+	JB LBB5_2
+LBB5_3:
+    SUBQ $8, SP
+    VZEROUPPER
+    RET
+
+
+
+
+DATA LCDATA6<>+0x000(SB)/8, $0x3fcc42293d372713
+DATA LCDATA6<>+0x008(SB)/8, $0xc2ae000042b00000
+DATA LCDATA6<>+0x010(SB)/8, $0x3f3180003fb8aa3b
+DATA LCDATA6<>+0x018(SB)/8, $0x3ab743ce395e8083
+DATA LCDATA6<>+0x020(SB)/8, $0x3c08890839506967
+DATA LCDATA6<>+0x028(SB)/8, $0x3e2aaaaa3d2aa9c1
+DATA LCDATA6<>+0x030(SB)/8, $0x3f8000003f000000
+GLOBL LCDATA6<>(SB), 8, $56
+
+TEXT ·_f32_gelu(SB), $168-16
+
+    MOVQ x+0(FP), DI
+    MOVQ n+8(FP), SI
+    ADDQ $8, SP
+    LEAQ LCDATA6<>(SB), BP
+
+    LONG $0x08fe8348             // cmp    rsi, 8
+	JB LBB6_3
+    LONG $0x000007b8; BYTE $0x00 // mov    eax, 7
+    LONG $0x187de2c4; WORD $0x0045 // vbroadcastss    ymm0, dword 0[rbp] /* [rip + .LCPI6_0] */
+    LONG $0x4411fcc5; WORD $0x6024 // vmovups    yword [rsp + 96], ymm0
+    LONG $0x187de2c4; WORD $0x0445 // vbroadcastss    ymm0, dword 4[rbp] /* [rip + .LCPI6_1] */
+    LONG $0x4411fcc5; WORD $0x4024 // vmovups    yword [rsp + 64], ymm0
+    LONG $0x187de2c4; WORD $0x0845 // vbroadcastss    ymm0, dword 8[rbp] /* [rip + .LCPI6_2] */
+    LONG $0x4411fcc5; WORD $0x2024 // vmovups    yword [rsp + 32], ymm0
+    LONG $0x187de2c4; WORD $0x0c45 // vbroadcastss    ymm0, dword 12[rbp] /* [rip + .LCPI6_3] */
+    LONG $0x0411fcc5; BYTE $0x24 // vmovups    yword [rsp], ymm0
+    LONG $0x187de2c4; WORD $0x1065 // vbroadcastss    ymm4, dword 16[rbp] /* [rip + .LCPI6_4] */
+    LONG $0x187de2c4; WORD $0x146d // vbroadcastss    ymm5, dword 20[rbp] /* [rip + .LCPI6_5] */
+    LONG $0x187de2c4; WORD $0x1875 // vbroadcastss    ymm6, dword 24[rbp] /* [rip + .LCPI6_6] */
+    LONG $0x187de2c4; WORD $0x1c7d // vbroadcastss    ymm7, dword 28[rbp] /* [rip + .LCPI6_7] */
+    LONG $0x187de2c4; WORD $0x2055 // vbroadcastss    ymm2, dword 32[rbp] /* [rip + .LCPI6_8] */
+    LONG $0x187d62c4; WORD $0x244d // vbroadcastss    ymm9, dword 36[rbp] /* [rip + .LCPI6_9] */
+    LONG $0x187d62c4; WORD $0x2855 // vbroadcastss    ymm10, dword 40[rbp] /* [rip + .LCPI6_10] */
+    LONG $0x187d62c4; WORD $0x2c5d // vbroadcastss    ymm11, dword 44[rbp] /* [rip + .LCPI6_11] */
+    LONG $0x187d62c4; WORD $0x3065 // vbroadcastss    ymm12, dword 48[rbp] /* [rip + .LCPI6_12] */
+    LONG $0x187d62c4; WORD $0x346d // vbroadcastss    ymm13, dword 52[rbp] /* [rip + .LCPI6_13] */
+    LONG $0x587d62c4; WORD $0x3475 // vpbroadcastd    ymm14, dword 52[rbp] /* [rip + .LCPI6_13] */
+LBB6_2:
+    LONG $0x7c107cc5; WORD $0xe487 // vmovups    ymm15, yword [rdi + 4*rax - 28]
+    LONG $0x5904c1c4; BYTE $0xc7 // vmulps    ymm0, ymm15, ymm15
+    LONG $0x4459fcc5; WORD $0x6024 // vmulps    ymm0, ymm0, yword [rsp + 96]
+    LONG $0xae05c2c4; BYTE $0xc7 // vfnmsub213ps    ymm0, ymm15, ymm15
+    LONG $0x4459fcc5; WORD $0x4024 // vmulps    ymm0, ymm0, yword [rsp + 64]
+    LONG $0x445dfcc5; WORD $0x2024 // vminps    ymm0, ymm0, yword [rsp + 32]
+    LONG $0x045ffcc5; BYTE $0x24 // vmaxps    ymm0, ymm0, yword [rsp]
+    LONG $0xcc59fcc5             // vmulps    ymm1, ymm0, ymm4
+    LONG $0x087de3c4; WORD $0x08c9 // vroundps    ymm1, ymm1, 8
+    LONG $0xba75e2c4; BYTE $0xc5 // vfmsub231ps    ymm0, ymm1, ymm5
+    LONG $0xba75e2c4; BYTE $0xc6 // vfmsub231ps    ymm0, ymm1, ymm6
+    LONG $0xc2287cc5             // vmovaps    ymm8, ymm2
+    LONG $0xa87d62c4; BYTE $0xc7 // vfmadd213ps    ymm8, ymm0, ymm7
+    LONG $0xa87d42c4; BYTE $0xc1 // vfmadd213ps    ymm8, ymm0, ymm9
+    LONG $0xa87d42c4; BYTE $0xc2 // vfmadd213ps    ymm8, ymm0, ymm10
+    LONG $0xa87d42c4; BYTE $0xc3 // vfmadd213ps    ymm8, ymm0, ymm11
+    LONG $0xa87d42c4; BYTE $0xc4 // vfmadd213ps    ymm8, ymm0, ymm12
+    LONG $0xd859fcc5             // vmulps    ymm3, ymm0, ymm0
+    LONG $0xa83de2c4; BYTE $0xd8 // vfmadd213ps    ymm3, ymm8, ymm0
+    LONG $0xc35894c5             // vaddps    ymm0, ymm13, ymm3
+    LONG $0xc95bfdc5             // vcvtps2dq    ymm1, ymm1
+    LONG $0xf172f5c5; BYTE $0x17 // vpslld    ymm1, ymm1, 23
+    LONG $0xc9fe8dc5             // vpaddd    ymm1, ymm14, ymm1
+    LONG $0xa87dc2c4; BYTE $0xcd // vfmadd213ps    ymm1, ymm0, ymm13
+    LONG $0xc153fcc5             // vrcpps    ymm0, ymm1
+    LONG $0xd85984c5             // vmulps    ymm3, ymm15, ymm0
+    LONG $0xaa65c2c4; BYTE $0xcf // vfmsub213ps    ymm1, ymm3, ymm15
+    LONG $0xac7de2c4; BYTE $0xcb // vfnmadd213ps    ymm1, ymm0, ymm3
+    LONG $0x4c11fcc5; WORD $0xe487 // vmovups    yword [rdi + 4*rax - 28], ymm1
+    LONG $0x08c08348             // add    rax, 8
+    WORD $0x3948; BYTE $0xf0     // cmp    rax, rsi
+	JB LBB6_2
+LBB6_3:
+    SUBQ $8, SP
+    VZEROUPPER
+    RET
+
+
+
+
+DATA LCDATA7<>+0x000(SB)/8, $0x42b0000080000000
+DATA LCDATA7<>+0x008(SB)/8, $0x3fb8aa3bc2ae0000
+DATA LCDATA7<>+0x010(SB)/8, $0x395e80833f318000
+DATA LCDATA7<>+0x018(SB)/8, $0x395069673ab743ce
+DATA LCDATA7<>+0x020(SB)/8, $0x3d2aa9c13c088908
+DATA LCDATA7<>+0x028(SB)/8, $0x3f0000003e2aaaaa
+DATA LCDATA7<>+0x030(SB)/8, $0x400000003f800000
+DATA LCDATA7<>+0x038(SB)/8, $0x3de38e393e124925
+DATA LCDATA7<>+0x040(SB)/8, $0x3eaaaaab3e4ccccd
+DATA LCDATA7<>+0x048(SB)/8, $0x0000000000000000
+DATA LCDATA7<>+0x050(SB)/8, $0x0000000000000000
+DATA LCDATA7<>+0x058(SB)/8, $0x0000000000000000
+DATA LCDATA7<>+0x060(SB)/8, $0x0000000000000000
+DATA LCDATA7<>+0x068(SB)/8, $0x0000000000000000
+DATA LCDATA7<>+0x070(SB)/8, $0x0000000000000000
+DATA LCDATA7<>+0x078(SB)/8, $0x0000000000000000
+GLOBL LCDATA7<>(SB), 8, $128
+
+TEXT ·_f32_softplus(SB), $296-16
+
+    MOVQ x+0(FP), DI
+    MOVQ n+8(FP), SI
+    ADDQ $8, SP
+    LEAQ LCDATA7<>(SB), BP
+
+    LONG $0x08fe8348             // cmp    rsi, 8
+	JB LBB7_3
+    LONG $0x000007b8; BYTE $0x00 // mov    eax, 7
+    LONG $0x187de2c4; WORD $0x0045 // vbroadcastss    ymm0, dword 0[rbp] /* [rip + .LCPI7_0] */
+    QUAD $0x0000e0248411fcc5; BYTE $0x00 // vmovups    yword [rsp + 224], ymm0
+    LONG $0x187de2c4; WORD $0x0445 // vbroadcastss    ymm0, dword 4[rbp] /* [rip + .LCPI7_1] */
+    QUAD $0x0000c0248411fcc5; BYTE $0x00 // vmovups    yword [rsp + 192], ymm0
+    LONG $0x187de2c4; WORD $0x0845 // vbroadcastss    ymm0, dword 8[rbp] /* [rip + .LCPI7_2] */
+    QUAD $0x0000a0248411fcc5; BYTE $0x00 // vmovups    yword [rsp + 160], ymm0
+    LONG $0x187de2c4; WORD $0x0c45 // vbroadcastss    ymm0, dword 12[rbp] /* [rip + .LCPI7_3] */
+    QUAD $0x000080248411fcc5; BYTE $0x00 // vmovups    yword [rsp + 128], ymm0
+    LONG $0x187de2c4; WORD $0x1045 // vbroadcastss    ymm0, dword 16[rbp] /* [rip + .LCPI7_4] */
+    LONG $0x4411fcc5; WORD $0x6024 // vmovups    yword [rsp + 96], ymm0
+    LONG $0x187de2c4; WORD $0x1445 // vbroadcastss    ymm0, dword 20[rbp] /* [rip + .LCPI7_5] */
+    LONG $0x4411fcc5; WORD $0x4024 // vmovups    yword [rsp + 64], ymm0
+    LONG $0x187de2c4; WORD $0x1845 // vbroadcastss    ymm0, dword 24[rbp] /* [rip + .LCPI7_6] */
+    LONG $0x4411fcc5; WORD $0x2024 // vmovups    yword [rsp + 32], ymm0
+    LONG $0x187de2c4; WORD $0x1c45 // vbroadcastss    ymm0, dword 28[rbp] /* [rip + .LCPI7_7] */
+    LONG $0x0411fcc5; BYTE $0x24 // vmovups    yword [rsp], ymm0
+    LONG $0x187d62c4; WORD $0x2045 // vbroadcastss    ymm8, dword 32[rbp] /* [rip + .LCPI7_8] */
+    LONG $0x187d62c4; WORD $0x244d // vbroadcastss    ymm9, dword 36[rbp] /* [rip + .LCPI7_9] */
+    LONG $0x187d62c4; WORD $0x2855 // vbroadcastss    ymm10, dword 40[rbp] /* [rip + .LCPI7_10] */
+    LONG $0x187d62c4; WORD $0x2c5d // vbroadcastss    ymm11, dword 44[rbp] /* [rip + .LCPI7_11] */
+    LONG $0x187d62c4; WORD $0x3065 // vbroadcastss    ymm12, dword 48[rbp] /* [rip + .LCPI7_12] */
+    LONG $0x587d62c4; WORD $0x306d // vpbroadcastd    ymm13, dword 48[rbp] /* [rip + .LCPI7_12] */
+    LONG $0x187d62c4; WORD $0x347d // vbroadcastss    ymm15, dword 52[rbp] /* [rip + .LCPI7_13] */
+    LONG $0x187d62c4; WORD $0x3875 // vbroadcastss    ymm14, dword 56[rbp] /* [rip + .LCPI7_14] */
+    LONG $0x187de2c4; WORD $0x3c45 // vbroadcastss    ymm0, dword 60[rbp] /* [rip + .LCPI7_15] */
+    LONG $0x187de2c4; WORD $0x404d // vbroadcastss    ymm1, dword 64[rbp] /* [rip + .LCPI7_16] */
+    LONG $0x187de2c4; WORD $0x4455 // vbroadcastss    ymm2, dword 68[rbp] /* [rip + .LCPI7_17] */
+LBB7_2:
+    LONG $0x5c10fcc5; WORD $0xe487 // vmovups    ymm3, yword [rdi + 4*rax - 28]
+    QUAD $0x0000e024a456e4c5; BYTE $0x00 // vorps    ymm4, ymm3, yword [rsp + 224]
+    QUAD $0x0000c024a45ddcc5; BYTE $0x00 // vminps    ymm4, ymm4, yword [rsp + 192]
+    QUAD $0x0000a024a45fdcc5; BYTE $0x00 // vmaxps    ymm4, ymm4, yword [rsp + 160]
+    QUAD $0x00008024ac59dcc5; BYTE $0x00 // vmulps    ymm5, ymm4, yword [rsp + 128]
+    LONG $0x087de3c4; WORD $0x08ed // vroundps    ymm5, ymm5, 8
+    LONG $0xba55e2c4; WORD $0x2464; BYTE $0x60 // vfmsub231ps    ymm4, ymm5, yword [rsp + 96]
+    LONG $0xba55e2c4; WORD $0x2464; BYTE $0x40 // vfmsub231ps    ymm4, ymm5, yword [rsp + 64]
+    LONG $0x3c10fcc5; BYTE $0x24 // vmovups    ymm7, yword [rsp]
+    LONG $0xa85de2c4; WORD $0x247c; BYTE $0x20 // vfmadd213ps    ymm7, ymm4, yword [rsp + 32]
+    LONG $0xa85dc2c4; BYTE $0xf8 // vfmadd213ps    ymm7, ymm4, ymm8
+    LONG $0xa85dc2c4; BYTE $0xf9 // vfmadd213ps    ymm7, ymm4, ymm9
+    LONG $0xa85dc2c4; BYTE $0xfa // vfmadd213ps    ymm7, ymm4, ymm10
+    LONG $0xa85dc2c4; BYTE $0xfb // vfmadd213ps    ymm7, ymm4, ymm11
+    LONG $0xf459dcc5             // vmulps    ymm6, ymm4, ymm4
+    LONG $0xa845e2c4; BYTE $0xf4 // vfmadd213ps    ymm6, ymm7, ymm4
+    LONG $0xe55bfdc5             // vcvtps2dq    ymm4, ymm5
+    LONG $0xf472ddc5; BYTE $0x17 // vpslld    ymm4, ymm4, 23
+    LONG $0xe4fe95c5             // vpaddd    ymm4, ymm13, ymm4
+    LONG $0xa84de2c4; BYTE $0xe4 // vfmadd213ps    ymm4, ymm6, ymm4
+    LONG $0xec5884c5             // vaddps    ymm5, ymm15, ymm4
+    LONG $0xf553fcc5             // vrcpps    ymm6, ymm5
+    LONG $0xfe59dcc5             // vmulps    ymm7, ymm4, ymm6
+    LONG $0xba45e2c4; BYTE $0xe5 // vfmsub231ps    ymm4, ymm7, ymm5
+    LONG $0xac4de2c4; BYTE $0xe7 // vfnmadd213ps    ymm4, ymm6, ymm7
+    LONG $0xec59dcc5             // vmulps    ymm5, ymm4, ymm4
+    LONG $0xf028fcc5             // vmovaps    ymm6, ymm0
+    LONG $0xa855c2c4; BYTE $0xf6 // vfmadd213ps    ymm6, ymm5, ymm14
+    LONG $0xa855e2c4; BYTE $0xf1 // vfmadd213ps    ymm6, ymm5, ymm1
+    LONG $0xa855e2c4; BYTE $0xf2 // vfmadd213ps    ymm6, ymm5, ymm2
+    LONG $0xa855c2c4; BYTE $0xf4 // vfmadd213ps    ymm6, ymm5, ymm12
+    LONG $0x5d5fe4c5; BYTE $0x60 // vmaxps    ymm3, ymm3, yword 96[rbp] /* [rip + .LCPI7_18] */
+    LONG $0xe458dcc5             // vaddps    ymm4, ymm4, ymm4
+    LONG $0xa84de2c4; BYTE $0xe3 // vfmadd213ps    ymm4, ymm6, ymm3
+    LONG $0x6411fcc5; WORD $0xe487 // vmovups    yword [rdi + 4*rax - 28], ymm4
+    LONG $0x08c08348             // add    rax, 8
+    WORD $0x3948; BYTE $0xf0     // cmp    rax, rsi
+	JB LBB7_2
+LBB7_3:
+    SUBQ $8, SP
+    VZEROUPPER
+    RET
@@ -61,7 +61,7 @@ func Matmul(dst, m, n *Matrix) {
 func Axpy(dst, x []float32, alpha float32) {
 	switch {
 	case avx2:
-		_f32_axpy(unsafe.Pointer(&x[0]), unsafe.Pointer(&dst[0]), uint64(len(dst)), alpha)
+		_f32_axpy(unsafe.Pointer(&x[0]), unsafe.Pointer(&dst[0]), uint64(len(dst)), unsafe.Pointer(&alpha))
 	default:
 		_axpy(x, dst, alpha)
 	}
@@ -113,32 +113,144 @@ func Sub(dst, src []float32) {
 
 // ---------------------------------- Activations ----------------------------------
 
+// Relu applies the rectified linear unit, max(x, 0), in place
+func Relu(x []float32) {
+	n := 0
+	if avx2 && len(x) >= 8 {
+		n = len(x) &^ 7
+		_f32_relu(unsafe.Pointer(&x[0]), uint64(n))
+	}
+
+	for i, v := range x[n:] {
+		if v < 0 {
+			x[n+i] = 0
+		}
+	}
+}
+
+// Lrelu applies the leaky rectified linear unit with a slope of 0.01, in place
+func Lrelu(x []float32) {
+	LeakyRelu(x, 0.01)
+}
+
+// LeakyRelu applies the leaky rectified linear unit with the specified slope, in place
+func LeakyRelu(x []float32, slope float32) {
+	n := 0
+	if avx2 && len(x) >= 8 {
+		n = len(x) &^ 7
+		_f32_lrelu(unsafe.Pointer(&x[0]), uint64(n), unsafe.Pointer(&slope))
+	}
+
+	for i, v := range x[n:] {
+		if v < 0 {
+			x[n+i] = slope * v
+		}
+	}
+}
+
+// Exp computes e^x in place
+func Exp(x []float32) {
+	n := 0
+	if avx2 && len(x) >= 8 {
+		n = len(x) &^ 7
+		_f32_exp(unsafe.Pointer(&x[0]), uint64(n))
+	}
+
+	for i, v := range x[n:] {
+		x[n+i] = float32(math.Exp(float64(v)))
+	}
+}
+
+// Sigmoid applies the logistic function, 1 / (1 + e^-x), in place
 func Sigmoid(x []float32) {
-	for i, v := range x {
-		x[i] = 1 / (1 + float32(math.Exp(-float64(v))))
+	n := 0
+	if avx2 && len(x) >= 8 {
+		n = len(x) &^ 7
+		_f32_sigmoid(unsafe.Pointer(&x[0]), uint64(n))
+	}
+
+	for i, v := range x[n:] {
+		x[n+i] = sigmoid(v)
 	}
 }
 
+// Tanh applies the hyperbolic tangent in place
 func Tanh(x []float32) {
-	for i, v := range x {
-		x[i] = 2/(1+float32(math.Exp(-2*float64(v)))) - 1
+	n := 0
+	if avx2 && len(x) >= 8 {
+		n = len(x) &^ 7
+		_f32_tanh(unsafe.Pointer(&x[0]), uint64(n))
+	}
+
+	for i, v := range x[n:] {
+		x[n+i] = 2*sigmoid(2*v) - 1
 	}
 }
 
+// Swish applies the swish (or SiLU) function, x·sigmoid(x), in place
 func Swish(x []float32) {
-	for i, v := range x {
-		x[i] = v / (1 + float32(math.Exp(-float64(v))))
+	n := 0
+	if avx2 && len(x) >= 8 {
+		n = len(x) &^ 7
+		_f32_swish(unsafe.Pointer(&x[0]), uint64(n))
+	}
+
+	for i, v := range x[n:] {
+		x[n+i] = v * sigmoid(v)
 	}
 }
 
-func Lrelu(x []float32) {
-	for i, v := range x {
-		if v < 0 {
-			x[i] = 0.01 * v
-		}
+// Gelu applies the gaussian error linear unit in place, using its tanh approximation
+func Gelu(x []float32) {
+	n := 0
+	if avx2 && len(x) >= 8 {
+		n = len(x) &^ 7
+		_f32_gelu(unsafe.Pointer(&x[0]), uint64(n))
+	}
+
+	for i, v := range x[n:] {
+		x[n+i] = v * sigmoid(1.5957691*(v+0.044715*v*v*v))
+	}
+}
+
+// Softplus applies the softplus function, ln(1 + e^x), in place
+func Softplus(x []float32) {
+	n := 0
+	if avx2 && len(x) >= 8 {
+		n = len(x) &^ 7
+		_f32_softplus(unsafe.Pointer(&x[0]), uint64(n))
+	}
+
+	for i, v := range x[n:] {
+		abs := math.Abs(float64(v))
+		x[n+i] = float32(math.Max(float64(v), 0) + math.Log1p(math.Exp(-abs)))
 	}
 }
 
+// Softmax normalizes the values into a probability distribution, in place
+func Softmax(x []float32) {
+	if len(x) == 0 {
+		return
+	}
+
+	// Shift the values for numerical stability
+	max := simd.MaxFloat32s(x)
+	for i := range x {
+		x[i] -= max
+	}
+
+	Exp(x)
+	scale := 1 / simd.SumFloat32s(x)
+	for i := range x {
+		x[i] *= scale
+	}
+}
+
+// sigmoid computes the logistic function of a single value
+func sigmoid(x float32) float32 {
+	return 1 / (1 + float32(math.Exp(-float64(x))))
+}
+
 // ---------------------------------- Matrix ----------------------------------
 
 // Matrix represents a Matrix using the conventional storage scheme.
@@ -225,6 +337,15 @@ func (m *Matrix) Reset(rows, cols int) {
 	m.Zero()
 }
 
+// Clone returns a copy of the matrix
+func (m *Matrix) Clone() Matrix {
+	return Matrix{
+		Rows: m.Rows,
+		Cols: m.Cols,
+		Data: append([]float32(nil), m.Data...),
+	}
+}
+
 // Zero zeroes the matrix data, but does not change its shape
 func (m *Matrix) Zero() {
 	Clear(m.Data)
@@ -9,7 +9,7 @@ package math32
 import "unsafe"
 
 //go:noescape,nosplit
-func _f32_axpy(x, y unsafe.Pointer, size uint64, alpha float32) {
+func _f32_axpy(x, y unsafe.Pointer, size uint64, alpha unsafe.Pointer) {
 	panic("not supported")
 }
 
@@ -17,3 +17,43 @@ func _f32_axpy(x, y unsafe.Pointer, size uint64, alpha float32) {
 func _f32_matmul(dst, m, n unsafe.Pointer, mr, mc, nr, nc uint64) {
 	panic("not supported")
 }
+
+//go:noescape,nosplit
+func _f32_relu(x unsafe.Pointer, n uint64) {
+	panic("not supported")
+}
+
+//go:noescape,nosplit
+func _f32_lrelu(x unsafe.Pointer, n uint64, slope unsafe.Pointer) {
+	panic("not supported")
+}
+
+//go:noescape,nosplit
+func _f32_exp(x unsafe.Pointer, n uint64) {
+	panic("not supported")
+}
+
+//go:noescape,nosplit
+func _f32_sigmoid(x unsafe.Pointer, n uint64) {
+	panic("not supported")
+}
+
+//go:noescape,nosplit
+func _f32_tanh(x unsafe.Pointer, n uint64) {
+	panic("not supported")
+}
+
+//go:noescape,nosplit
+func _f32_swish(x unsafe.Pointer, n uint64) {
+	panic("not supported")
+}
+
+//go:noescape,nosplit
+func _f32_gelu(x unsafe.Pointer, n uint64) {
+	panic("not supported")
+}
+
+//go:noescape,nosplit
+func _f32_softplus(x unsafe.Pointer, n uint64) {
+	panic("not supported")
+}
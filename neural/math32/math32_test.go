@@ -30,13 +30,14 @@ func BenchmarkAXPY(b *testing.B) {
 	})
 
 	b.Run("asm", func(b *testing.B) {
+		alpha := float32(3)
 		b.ReportAllocs()
 		b.ResetTimer()
 		for i := 0; i < b.N; i++ {
 			_f32_axpy(
 				unsafe.Pointer(&x[0]),
 				unsafe.Pointer(&y[0]),
-				4, 3.0,
+				4, unsafe.Pointer(&alpha),
 			)
 		}
 	})
@@ -82,6 +83,42 @@ func BenchmarkMatmul(b *testing.B) {
 	}
 }
 
+/*
+cpu: Intel(R) Xeon(R) Processor
+BenchmarkActivations/relu         	 8963317	       134.9 ns/op	       0 B/op	       0 allocs/op
+BenchmarkActivations/lrelu        	 6492138	       176.2 ns/op	       0 B/op	       0 allocs/op
+BenchmarkActivations/sigmoid      	 1721769	       642.4 ns/op	       0 B/op	       0 allocs/op
+BenchmarkActivations/tanh         	 1607719	       762.0 ns/op	       0 B/op	       0 allocs/op
+BenchmarkActivations/swish        	 1410352	       782.8 ns/op	       0 B/op	       0 allocs/op
+BenchmarkActivations/gelu         	 1378773	       840.6 ns/op	       0 B/op	       0 allocs/op
+BenchmarkActivations/softplus     	 1000000	      1177 ns/op	       0 B/op	       0 allocs/op
+BenchmarkActivations/softmax      	  726200	      2091 ns/op	       0 B/op	       0 allocs/op
+*/
+func BenchmarkActivations(b *testing.B) {
+	x := make([]float32, 1024)
+	for _, tc := range []struct {
+		name string
+		fn   func([]float32)
+	}{
+		{"relu", Relu},
+		{"lrelu", Lrelu},
+		{"sigmoid", Sigmoid},
+		{"tanh", Tanh},
+		{"swish", Swish},
+		{"gelu", Gelu},
+		{"softplus", Softplus},
+		{"softmax", Softmax},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				tc.fn(x)
+			}
+		})
+	}
+}
+
 func TestApproxSwish(t *testing.T) {
 	mae := testApproxSwish(-10, 10)
 	assert.InDelta(t, 0, mae, 0.10, "unexpected MAE: %.5f", mae)
@@ -131,16 +168,29 @@ func TestGenericMatmul(t *testing.T) {
 func TestAXPY(t *testing.T) {
 	x := []float32{1, 2, 3, 4}
 	y := []float32{1, 1, 1, 1}
+	alpha := float32(2)
 
 	_f32_axpy(
 		unsafe.Pointer(&x[0]),
 		unsafe.Pointer(&y[0]),
-		4, 2,
+		4, unsafe.Pointer(&alpha),
 	)
 
 	_ = x[0]
 	_ = y[0]
 	assert.Equal(t, []float32{3, 5, 7, 9}, y)
+
+	// Both the vectorized loop and the tail use the same alpha
+	x = make([]float32, 19)
+	y = make([]float32, 19)
+	expect := make([]float32, 19)
+	for i := range x {
+		x[i], y[i] = float32(i), 1
+		expect[i] = 1 - 0.5*float32(i)
+	}
+
+	Axpy(y, x, -0.5)
+	assert.Equal(t, expect, y)
 }
 
 // axpyRef function, this doesn't use any SIMD as it seems like this version
@@ -179,3 +229,84 @@ func TestSub(t *testing.T) {
 	Sub(mx1.Data, mx2.Data)
 	assert.Equal(t, []float32{1, 1, 1, 1}, mx1.Data)
 }
+
+func TestActivations(t *testing.T) {
+	sigmoid := func(x float64) float64 { return 1 / (1 + math.Exp(-x)) }
+	tests := []struct {
+		name   string
+		fn     func([]float32)
+		expect func(float64) float64
+	}{
+		{"relu", Relu, func(x float64) float64 { return math.Max(x, 0) }},
+		{"lrelu", func(x []float32) { LeakyRelu(x, 0.2) }, func(x float64) float64 { return math.Max(x, 0.2*x) }},
+		{"exp", Exp, math.Exp},
+		{"sigmoid", Sigmoid, sigmoid},
+		{"tanh", Tanh, math.Tanh},
+		{"swish", Swish, func(x float64) float64 { return x * sigmoid(x) }},
+		{"softplus", Softplus, func(x float64) float64 { return math.Log1p(math.Exp(x)) }},
+		{"gelu", Gelu, func(x float64) float64 {
+			return 0.5 * x * (1 + math.Tanh(math.Sqrt(2/math.Pi)*(x+0.044715*x*x*x)))
+		}},
+	}
+
+	for _, tc := range tests {
+		runKernels(t, tc.name, func(t *testing.T) {
+			x := make([]float32, 0, 1003)
+			for v := -20.0; len(x) < cap(x); v += 0.04 {
+				x = append(x, float32(v))
+			}
+
+			input := append([]float32(nil), x...)
+			tc.fn(x)
+			for i, v := range input {
+				expect := tc.expect(float64(v))
+				assert.InDelta(t, expect, x[i], 1e-5+math.Abs(expect)*1e-5, "%s(%v)", tc.name, v)
+			}
+		})
+	}
+}
+
+func TestActivationsSaturate(t *testing.T) {
+	runKernels(t, "saturate", func(t *testing.T) {
+		x := []float32{-1000, -100, 100, 1000, -1000, -100, 100, 1000}
+		Sigmoid(x)
+		assert.InDeltaSlice(t, []float32{0, 0, 1, 1, 0, 0, 1, 1}, x, 1e-6)
+
+		x = []float32{-1000, -100, 100, 1000, -1000, -100, 100, 1000}
+		Tanh(x)
+		assert.InDeltaSlice(t, []float32{-1, -1, 1, 1, -1, -1, 1, 1}, x, 1e-6)
+
+		x = []float32{-1000, -100, 100, 1000, -1000, -100, 100, 1000}
+		Softplus(x)
+		assert.InDeltaSlice(t, []float32{0, 0, 100, 1000, 0, 0, 100, 1000}, x, 1e-6)
+	})
+}
+
+// runKernels runs the test with the assembly kernels, if the CPU supports them, and then
+// with the pure Go fallback which is used on every other platform
+func runKernels(t *testing.T, name string, fn func(t *testing.T)) {
+	supported := avx2
+	defer func() { avx2 = supported }()
+
+	if supported {
+		t.Run(name+"/asm", fn)
+	}
+
+	avx2 = false
+	t.Run(name+"/generic", fn)
+}
+
+func TestSoftmax(t *testing.T) {
+	x := []float32{1, 2, 3, 4, 1, 2, 3, 4, 1000}
+	Softmax(x)
+	assert.InDelta(t, 1, x[8], 1e-6)
+
+	x = []float32{1, 2, 3}
+	Softmax(x)
+	assert.InDelta(t, 0.09003057, x[0], 1e-6)
+	assert.InDelta(t, 0.24472847, x[1], 1e-6)
+	assert.InDelta(t, 0.66524096, x[2], 1e-6)
+	assert.NotPanics(t, func() {
+		Softmax(nil)
+	})
+}
@@ -5,18 +5,60 @@ package neural
 
 import (
 	"encoding/json"
+	"fmt"
 	"sync"
 
-	"github.com/kelindar/evolve"
 	"github.com/kelindar/evolve/neural/layer"
 	"github.com/kelindar/evolve/neural/math32"
 )
 
-// Layer represents a single layer
+// Layer represents a single layer, whose recurrent state is kept separately in a layer.State
+// so that the same layer can be evaluated for many sessions at once
 type Layer interface {
-	evolve.Genome
-	Update(dst, x *math32.Matrix) *math32.Matrix
-	Reset()
+	Forward(dst, x *math32.Matrix, state *layer.State) *math32.Matrix
+	Weights() []*math32.Matrix
+	Mutate()
+}
+
+// Typed represents a layer of the concrete type T, which can be cloned and crossed over with
+// the layers of the same type
+type Typed[T any] interface {
+	Layer
+	Clone() T
+	Crossover(l1, l2 T)
+}
+
+// node represents a layer of the network, along with its specification, its weights and the
+// operators bound to its concrete type
+type node struct {
+	Layer
+	spec      Spec               // The specification of the layer
+	weights   []*math32.Matrix   // The weight matrices, in the order they are serialized
+	clone     func() node        // Clones the layer
+	crossover func(l1, l2 *node) // Performs crossover between two layers of the same type
+}
+
+// bind binds the layer to its typed operators, so that the network never needs to switch
+// on the concrete type of its layers
+func bind[T Typed[T]](l T, spec Spec) node {
+	return node{
+		Layer:   l,
+		spec:    spec,
+		weights: l.Weights(),
+		clone: func() node {
+			return bind(l.Clone(), spec)
+		},
+		crossover: func(l1, l2 *node) {
+			p1, ok1 := l1.Layer.(T)
+			p2, ok2 := l2.Layer.(T)
+			switch {
+			case ok1 && ok2:
+				l.Crossover(p1, p2)
+			case ok1:
+				l.Crossover(p1, p1) // Copy the first parent if the second one has another type
+			}
+		},
+	}
 }
 
 // Network represents a feed forward neural network
@@ -25,36 +67,80 @@ type Network struct {
 	shape      []int
 	sensorSize int
 	outputSize int
-	weights    []math32.Matrix
-	scratch    [2]math32.Matrix
-	layers     []Layer
+	state      *State    // The recurrent state used by Predict
+	training   *training // The buffers used by Train, allocated on first use
+	layers     []node
 }
 
-// NewNetwork creates a new NeuralNetwork
+// NewNetwork creates a new NeuralNetwork of the specified shape, where every hidden and output
+// layer is an MGU layer. Optionally, the weights of every matrix can be provided in the same
+// order as they are serialized, layer after layer.
 func NewNetwork(shape []int, weights ...[]float32) *Network {
+	arch := Architecture{Inputs: shape[0]}
+	for _, size := range shape[1:] {
+		arch.Layers = append(arch.Layers, Spec{Type: MGU, Size: size})
+	}
+
+	return NewNetworkFrom(arch, weights...)
+}
+
+// NewNetworkFrom creates a new NeuralNetwork with the specified architecture. Optionally, the
+// weights of every matrix can be provided in the same order as they are serialized.
+func NewNetworkFrom(arch Architecture, weights ...[]float32) *Network {
+	layers, err := arch.build()
+	if err != nil {
+		panic(err)
+	}
+
+	shape := arch.Shape()
 	nn := &Network{
 		shape:      shape,
 		sensorSize: shape[0],
 		outputSize: shape[len(shape)-1],
+		layers:     layers,
 	}
+	nn.state = nn.NewState()
 
-	// Create weight matrices for each prev
-	prev := nn.sensorSize
-	for _, hidden := range shape[1:] {
-		nn.layers = append(nn.layers, layer.NewMGU(prev, hidden))
-		prev = hidden
+	// Optionally, construct a network from pre-defined values
+	if len(weights) > 0 {
+		nn.SetWeights(weights...)
 	}
+	return nn
+}
 
-	// Optionally, construct a network from pre-defined values
-	for i := range weights {
-		mx := nn.weights[i]
-		nn.weights[i] = math32.Matrix{
-			Rows: mx.Rows,
-			Cols: mx.Cols,
-			Data: weights[i],
+// SetWeights copies the weights of every matrix into the network, in the same order as they
+// are serialized, and clears the recurrent state used by Predict. It panics if the number or
+// the size of the matrices does not match the architecture.
+func (nn *Network) SetWeights(weights ...[]float32) {
+	nn.mu.Lock()
+	defer nn.mu.Unlock()
+
+	count := 0
+	for _, l := range nn.layers {
+		count += len(l.weights)
+	}
+
+	if len(weights) != count {
+		panic(fmt.Errorf("neural: expected %d weight matrices, got %d", count, len(weights)))
+	}
+
+	i := 0
+	for _, l := range nn.layers {
+		for _, mx := range l.weights {
+			if len(weights[i]) != len(mx.Data) {
+				panic(fmt.Errorf("neural: expected %d weights for matrix %d, got %d", len(mx.Data), i, len(weights[i])))
+			}
+			copy(mx.Data, weights[i])
+			i++
 		}
 	}
-	return nn
+
+	nn.state.Reset()
+}
+
+// Architecture returns the architecture of the network
+func (nn *Network) Architecture() Architecture {
+	return architectureOf(nn.sensorSize, nn.layers)
 }
 
 // Predict performs a forward propagation through the neural network
@@ -63,42 +149,29 @@ func (nn *Network) Predict(input, output []float32) []float32 {
 		output = make([]float32, nn.outputSize)
 	}
 
-	// Set the input matrix
-	layer := &math32.Matrix{
-		Rows: 1, Cols: len(input),
-		Data: input,
-	}
-
 	nn.mu.Lock()
 	defer nn.mu.Unlock()
-
-	for i := range nn.layers {
-		layer = nn.layers[i].Update(&nn.scratch[i%2], layer)
-	}
-
-	// Copy the output so we can release the lock
-	copy(output, layer.Data)
-	return output
+	return nn.PredictWith(nn.state, input, output)
 }
 
-// forward performs M·N matrix multiplication and writes the result to dst after applying ReLU
-func (nn *Network) forward(dst, m, n *math32.Matrix) *math32.Matrix {
-	dst.Reset(m.Rows, n.Cols)
-	math32.Matmul(dst, m, n)
-	math32.Lrelu(dst.Data)
-	return dst
+// PredictBatch performs a forward propagation of a batch of independent inputs, one per row
+// of the input matrix, and writes one row of outputs per input. Every row keeps its own
+// recurrent state, which is cleared whenever the batch size changes, so the same batch size
+// should be used for every step of the episodes. If the output is nil, a new matrix is allocated.
+func (nn *Network) PredictBatch(input, output *math32.Matrix) *math32.Matrix {
+	nn.mu.Lock()
+	defer nn.mu.Unlock()
+	return nn.PredictBatchWith(nn.state, input, output)
 }
 
-// Crossover performs crossover between two genomes
-func (nn *Network) Crossover(g1, g2 evolve.Genome) {
-	nn1 := g1.(*Network)
-	nn2 := g2.(*Network)
-
+// Crossover performs crossover between two genomes. The networks of a population share the
+// same architecture, hence the layers at the same position are of the same type.
+func (nn *Network) Crossover(nn1, nn2 *Network) {
 	nn.mu.Lock()
 	defer nn.mu.Unlock()
 
 	for i := range nn.layers {
-		nn.layers[i].Crossover(nn1.layers[i], nn2.layers[i])
+		nn.layers[i].crossover(&nn1.layers[i], &nn2.layers[i])
 	}
 }
 
@@ -111,13 +184,35 @@ func (nn *Network) Mutate() {
 	}
 }
 
+// Reset clears the recurrent state used by Predict
 func (nn *Network) Reset() {
-	for i := range nn.layers {
-		nn.layers[i].Reset()
+	nn.mu.Lock()
+	defer nn.mu.Unlock()
+	nn.state.Reset()
+}
+
+// Clone returns a copy of the network, with a zero recurrent state
+func (nn *Network) Clone() *Network {
+	nn.mu.Lock()
+	defer nn.mu.Unlock()
+
+	clone := &Network{
+		shape:      append([]int(nil), nn.shape...),
+		sensorSize: nn.sensorSize,
+		outputSize: nn.outputSize,
+		layers:     make([]node, 0, len(nn.layers)),
 	}
+
+	for _, l := range nn.layers {
+		clone.layers = append(clone.layers, l.clone())
+	}
+
+	clone.state = clone.NewState()
+	return clone
 }
 
+// String returns the network, along with its weights, encoded as JSON
 func (nn *Network) String() string {
-	out, _ := json.MarshalIndent(nn.weights, "", "\t")
+	out, _ := json.MarshalIndent(nn, "", "\t")
 	return string(out)
 }
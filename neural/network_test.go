@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/kelindar/evolve"
+	"github.com/kelindar/evolve/neural/math32"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -74,6 +75,42 @@ func BenchmarkDeep(b *testing.B) {
 	}
 }
 
+/*
+cpu: Intel(R) Xeon(R) Processor
+BenchmarkPredictBatch/networks         	    1621	    738759 ns/op	    3153 B/op	      64 allocs/op
+BenchmarkPredictBatch/batch            	    2568	    436321 ns/op	       0 B/op	       0 allocs/op
+*/
+func BenchmarkPredictBatch(b *testing.B) {
+	const batch = 64
+	nn := NewNetwork([]int{10, 128, 128, 4})
+	input := math32.NewMatrix(batch, 10, nil)
+
+	b.Run("networks", func(b *testing.B) {
+		networks := make([]*Network, batch)
+		for i := range networks {
+			networks[i] = nn.Clone()
+		}
+
+		out := make([]float32, 4)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			for j, network := range networks {
+				network.Predict(input.Data[j*10:(j+1)*10], out)
+			}
+		}
+	})
+
+	b.Run("batch", func(b *testing.B) {
+		var output math32.Matrix
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			nn.PredictBatch(&input, &output)
+		}
+	})
+}
+
 func TestXOR(t *testing.T) {
 	pop := evolve.New(64, evaluateXOR, func() *Network {
 		return NewNetwork([]int{2, 2, 1})
@@ -85,6 +122,66 @@ func TestXOR(t *testing.T) {
 	assert.InDelta(t, 1, evaluateXOR(pop.Evolve())/4, 0.01)
 }
 
+func TestClone(t *testing.T) {
+	nn := NewNetwork([]int{2, 4, 1})
+	in := []float32{0.5, 1}
+	before := nn.Predict(in, nil)
+
+	// The clone starts with a zero recurrent state
+	clone := nn.Clone()
+	assert.Equal(t, before, clone.Predict(in, nil))
+
+	// Mutating the clone must not affect the original
+	for i := 0; i < 10; i++ {
+		clone.Mutate()
+	}
+
+	nn.Reset()
+	assert.Equal(t, before, nn.Predict(in, nil))
+}
+
+func TestPredictBatch(t *testing.T) {
+	const batch = 4
+	arch := Architecture{
+		Inputs: 3,
+		Layers: []Spec{
+			{Type: FFN, Size: 8},
+			{Type: RNN, Size: 6},
+			{Type: RNN, Size: 6, Dense: true},
+			{Type: MGU, Size: 5},
+			{Type: MGU, Size: 5, Dense: true},
+			{Type: LSTM, Size: 4},
+			{Type: GRU, Size: 2},
+		},
+	}
+
+	// Every row must behave as an independent network
+	nn := NewNetworkFrom(arch)
+	rows := make([]*Network, batch)
+	for i := range rows {
+		rows[i] = nn.Clone()
+	}
+
+	input := math32.NewMatrix(batch, 3, nil)
+	var output math32.Matrix
+	for step := 0; step < 3; step++ {
+		for i := range input.Data {
+			input.Data[i] = float32(i%7) / 7 * float32(step+1)
+		}
+
+		nn.PredictBatch(&input, &output)
+		assert.Equal(t, batch, output.Rows)
+		assert.Equal(t, 2, output.Cols)
+		for i, row := range rows {
+			expect := row.Predict(input.Data[i*3:(i+1)*3], nil)
+			assert.InDeltaSlice(t, expect, output.Data[i*2:(i+1)*2], 1e-5)
+		}
+	}
+
+	// Changing the batch size clears the state
+	assert.Equal(t, nn.Clone().Predict(input.Data[:3], nil), nn.Predict(input.Data[:3], nil))
+}
+
 func evaluateXOR(g *Network) (score float32) {
 	tests := []struct {
 		input  []float32
@@ -0,0 +1,86 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package neural
+
+import (
+	"fmt"
+
+	"github.com/kelindar/evolve/neural/layer"
+	"github.com/kelindar/evolve/neural/math32"
+)
+
+// State represents the recurrent state of a network for a single session, such as an agent
+// or a game client, along with the scratch space needed for the inference. Every session
+// needs its own state, while the network itself can be shared between the sessions.
+type State struct {
+	layers  []layer.State
+	scratch [2]math32.Matrix
+}
+
+// NewState creates a new, zero recurrent state for the network
+func (nn *Network) NewState() *State {
+	return &State{
+		layers: make([]layer.State, len(nn.layers)),
+	}
+}
+
+// Reset clears the recurrent state
+func (s *State) Reset() {
+	for i := range s.layers {
+		s.layers[i].Reset()
+	}
+}
+
+// PredictWith performs a forward propagation through the neural network using the specified
+// state, which is updated. Since the network itself is not modified, it does not acquire any
+// lock and can be called concurrently with different states, as long as the network is not
+// bred or mutated at the same time. If the output is nil, a new slice is allocated.
+func (nn *Network) PredictWith(state *State, input, output []float32) []float32 {
+	if len(input) != nn.sensorSize {
+		panic(fmt.Errorf("neural: expected %d inputs, got %d", nn.sensorSize, len(input)))
+	}
+
+	if output == nil {
+		output = make([]float32, nn.outputSize)
+	}
+
+	// Set the input matrix
+	layer := nn.propagate(state, &math32.Matrix{
+		Rows: 1, Cols: len(input),
+		Data: input,
+	})
+
+	copy(output, layer.Data)
+	return output
+}
+
+// PredictBatchWith performs a forward propagation of a batch of independent inputs using the
+// specified state, which keeps one row of recurrent state per input. Similarly to PredictWith,
+// it does not acquire any lock. If the output is nil, a new matrix is allocated.
+func (nn *Network) PredictBatchWith(state *State, input, output *math32.Matrix) *math32.Matrix {
+	if input.Cols != nn.sensorSize {
+		panic(fmt.Errorf("neural: expected %d inputs per row, got %d", nn.sensorSize, input.Cols))
+	}
+
+	if output == nil {
+		output = new(math32.Matrix)
+	}
+
+	layer := nn.propagate(state, input)
+	output.Reset(layer.Rows, layer.Cols)
+	copy(output.Data, layer.Data)
+	return output
+}
+
+// propagate propagates the input through every layer, updating the state
+func (nn *Network) propagate(state *State, x *math32.Matrix) *math32.Matrix {
+	if len(state.layers) != len(nn.layers) {
+		panic(fmt.Errorf("neural: state of %d layers used with a network of %d layers", len(state.layers), len(nn.layers)))
+	}
+
+	for i, l := range nn.layers {
+		x = l.Forward(&state.scratch[i%2], x, &state.layers[i])
+	}
+	return x
+}
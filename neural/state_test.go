@@ -0,0 +1,114 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package neural
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/kelindar/evolve/neural/math32"
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+cpu: Intel(R) Xeon(R) Processor
+BenchmarkPredictWith/locked         	  221006	      5819 ns/op	      48 B/op	       1 allocs/op
+BenchmarkPredictWith/state          	  216565	      6434 ns/op	      48 B/op	       1 allocs/op
+*/
+func BenchmarkPredictWith(b *testing.B) {
+	nn := NewNetwork([]int{10, 128, 128, 4})
+	in := make([]float32, 10)
+
+	b.Run("locked", func(b *testing.B) {
+		b.ReportAllocs()
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			out := make([]float32, 4)
+			for pb.Next() {
+				nn.Predict(in, out)
+			}
+		})
+	})
+
+	b.Run("state", func(b *testing.B) {
+		b.ReportAllocs()
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			state := nn.NewState()
+			out := make([]float32, 4)
+			for pb.Next() {
+				nn.PredictWith(state, in, out)
+			}
+		})
+	})
+}
+
+func TestPredictWith(t *testing.T) {
+	const sessions = 8
+	nn := NewNetworkFrom(Architecture{
+		Inputs: 2,
+		Layers: []Spec{
+			{Type: FFN, Size: 8},
+			{Type: RNN, Size: 6, Dense: true},
+			{Type: MGU, Size: 5},
+			{Type: LSTM, Size: 4},
+			{Type: GRU, Size: 2},
+		},
+	})
+
+	// Every session must behave as an independent copy of the network
+	expect := make([][]float32, sessions)
+	for i := range expect {
+		clone := nn.Clone()
+		for step := 0; step < 5; step++ {
+			expect[i] = clone.Predict([]float32{float32(i), float32(step)}, nil)
+		}
+	}
+
+	var wg sync.WaitGroup
+	actual := make([][]float32, sessions)
+	for i := 0; i < sessions; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			state := nn.NewState()
+			for step := 0; step < 5; step++ {
+				actual[i] = nn.PredictWith(state, []float32{float32(i), float32(step)}, nil)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	assert.Equal(t, expect, actual)
+}
+
+func TestStateReset(t *testing.T) {
+	nn := NewNetworkFrom(Architecture{
+		Inputs: 2,
+		Layers: []Spec{{Type: LSTM, Size: 4}, {Type: FFN, Size: 1}},
+	})
+
+	in := []float32{1, 2}
+	state := nn.NewState()
+	first := nn.PredictWith(state, in, nil)
+	assert.NotEqual(t, first, nn.PredictWith(state, in, nil))
+
+	// The state is independent from the one used by Predict
+	assert.Equal(t, first, nn.Predict(in, nil))
+
+	state.Reset()
+	assert.Equal(t, first, nn.PredictWith(state, in, nil))
+
+	// The batched prediction works with a state as well
+	out := nn.PredictBatchWith(nn.NewState(), &math32.Matrix{Rows: 2, Cols: 2, Data: []float32{1, 2, 1, 2}}, nil)
+	assert.InDeltaSlice(t, append(first, first...), out.Data, 1e-6)
+
+	assert.Panics(t, func() {
+		NewNetwork([]int{2, 2, 2, 1}).PredictWith(state, in, nil)
+	})
+
+	assert.Panics(t, func() {
+		nn.PredictWith(state, []float32{1, 2, 3}, nil)
+	})
+}
@@ -0,0 +1,65 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package numeric
+
+import (
+	"math"
+)
+
+// Distance returns the euclidean distance between the two genomes. If the lengths are
+// different, the missing genes are considered to be zero.
+func (g *Vector[T]) Distance(other *Vector[T]) float64 {
+	v1, v2 := *g, *other
+	if len(v1) > len(v2) {
+		v1, v2 = v2, v1
+	}
+
+	var sum float64
+	for i, v := range v2 {
+		d := float64(v)
+		if i < len(v1) {
+			d -= float64(v1[i])
+		}
+		sum += d * d
+	}
+	return math.Sqrt(sum)
+}
+
+// Distance returns the euclidean distance between the two chromosomes
+func (c *Chromosome[T]) Distance(other *Chromosome[T]) float64 {
+	return c.Vector.Distance(&other.Vector)
+}
+
+// Variance computes the variance of every dimension across the genomes. A dimension with
+// a variance close to zero has converged. If the lengths are different, every dimension
+// only accounts for the genomes which contain it. A random sample of the population is
+// usually enough.
+func Variance[T Number](genomes ...*Vector[T]) []float64 {
+	length := 0
+	for _, g := range genomes {
+		if len(*g) > length {
+			length = len(*g)
+		}
+	}
+
+	// Accumulate the moments of each dimension
+	sum := make([]float64, length)
+	squares := make([]float64, length)
+	count := make([]int, length)
+	for _, g := range genomes {
+		for i, v := range *g {
+			sum[i] += float64(v)
+			squares[i] += float64(v) * float64(v)
+			count[i]++
+		}
+	}
+
+	variance := make([]float64, length)
+	for i := range variance {
+		n := float64(count[i])
+		mean := sum[i] / n
+		variance[i] = math.Max(squares[i]/n-mean*mean, 0)
+	}
+	return variance
+}
@@ -0,0 +1,31 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package numeric_test
+
+import (
+	"testing"
+
+	"github.com/kelindar/evolve/numeric"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistance(t *testing.T) {
+	v1, v2 := numeric.Float32s{1, 2}, numeric.Float32s{4, 6, 12}
+	assert.Equal(t, 13.0, v1.Distance(&v2))
+	assert.Equal(t, 13.0, v2.Distance(&v1))
+
+	c1 := numeric.NewChromosome(3, numeric.Operators[int]{})()
+	c2 := c1.Clone()
+	assert.Equal(t, 0.0, c1.Distance(c2))
+}
+
+func TestVariance(t *testing.T) {
+	variance := numeric.Variance(
+		&numeric.Float32s{1, 5},
+		&numeric.Float32s{3, 5, 7},
+	)
+
+	assert.Equal(t, []float64{1, 0, 0}, variance)
+	assert.Empty(t, numeric.Variance[float64]())
+}
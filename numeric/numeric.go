@@ -0,0 +1,177 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package numeric
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math"
+	mrand "math/rand"
+	"unsafe"
+
+	"github.com/kelindar/evolve/internal/sequence"
+)
+
+// Number represents a numeric type which can be evolved
+type Number interface {
+	~float32 | ~float64 | ~int
+}
+
+// Vector represents a generic numeric genome
+type Vector[T Number] []T
+
+// Float64s represents a float64 numeric genome
+type Float64s = Vector[float64]
+
+// Ints represents an integer numeric genome
+type Ints = Vector[int]
+
+// NewVector creates a function for a random genome of a specific numeric type
+func NewVector[T Number](length int) func() *Vector[T] {
+	return func() *Vector[T] {
+		result := make(Vector[T], length)
+		for i := 0; i < length; i++ {
+			result[i] = randValue[T]()
+		}
+		return &result
+	}
+}
+
+// String implement stringer interface
+func (g *Vector[T]) String() string {
+	if g == nil {
+		return "<nil>"
+	}
+
+	return fmt.Sprintf("%+v", *g)
+}
+
+// Reset resets the internal state, no-op in this case
+func (g *Vector[T]) Reset() {
+	// No state
+}
+
+// Clone returns a copy of the genome
+func (g *Vector[T]) Clone() *Vector[T] {
+	clone := append(Vector[T](nil), *g...)
+	return &clone
+}
+
+// Mutate mutates a random gene
+func (g *Vector[T]) Mutate() {
+	const rate = 0.02
+	if len(*g) == 0 || mrand.Float32() >= rate {
+		return
+	}
+
+	i := mrand.Int31n(int32(len(*g)))
+	(*g)[i] = mutate((*g)[i])
+}
+
+// Crossover implements a random binary crossover. The offspring has the length of the first
+// parent, and the genes which are missing in the second parent are copied from the first one.
+func (g *Vector[T]) Crossover(p1, p2 *Vector[T]) {
+	blend(g, p1, p2)
+}
+
+// blend blends the genes of both parents into the destination
+func blend[T Number](dst, p1, p2 *Vector[T]) {
+	v1, v2 := *p1, *p2
+	*dst = sequence.Resize(*dst, len(v1))
+	n := len(v1)
+	if len(v2) < n {
+		n = len(v2)
+	}
+
+	for i := 0; i < n; i++ {
+		(*dst)[i] = crossover(v1[i], v2[i])
+	}
+	copy((*dst)[n:], v1[n:])
+}
+
+// crossover calculates a crossover between 2 numbers
+func crossover[T Number](v1, v2 T) T {
+	delta := 0.10
+	switch {
+	case isInteger[T]():
+		return v1 + T(stochasticRound(float64(v2-v1)*delta))
+	case isNan(v1) && isNan(v2):
+		return randValue[T]()
+	case isNan(v1):
+		return v2
+	case isNan(v2) || v1 == v2:
+		return v1
+	default: // e.g. [5, 10], move by x% towards 10
+		return v1 + ((v2 - v1) * T(delta))
+	}
+}
+
+// mutate returns a mutated value of the gene. Real numbers are replaced by a random
+// value in [0, 1) while integers are nudged by a step proportional to their magnitude.
+func mutate[T Number](v T) T {
+	if !isInteger[T]() {
+		return T(mrand.Float64())
+	}
+
+	scale := math.Max(1, math.Abs(float64(v))*0.1)
+	step := T(math.Round(mrand.NormFloat64() * scale))
+	switch {
+	case step != 0:
+		return v + step
+	case mrand.Intn(2) == 0:
+		return v - 1
+	default:
+		return v + 1
+	}
+}
+
+// randValue generates a random value of the numeric type. Real numbers are generated
+// from random bits, and integers with a random magnitude so both span many scales.
+func randValue[T Number]() T {
+	var zero T
+	switch {
+	case isInteger[T]():
+		v := T(mrand.Int63n(1 << mrand.Intn(31)))
+		if mrand.Intn(2) == 0 {
+			return -v
+		}
+		return v
+	case unsafe.Sizeof(zero) == 4:
+		return T(randFloat32())
+	default:
+		return T(randFloat64())
+	}
+}
+
+// stochasticRound rounds the number up or down, randomly, proportionally to its fraction
+func stochasticRound(v float64) float64 {
+	floor := math.Floor(v)
+	if mrand.Float64() < v-floor {
+		return floor + 1
+	}
+	return floor
+}
+
+// isInteger returns whether the numeric type is an integer
+func isInteger[T Number]() bool {
+	half := 0.5
+	return T(half) == 0
+}
+
+func isNan[T Number](v T) bool {
+	return v != v
+}
+
+func randFloat32() float32 {
+	v := make([]byte, 4)
+	crand.Read(v)
+	return math.Float32frombits(binary.BigEndian.Uint32(v))
+}
+
+func randFloat64() float64 {
+	v := make([]byte, 8)
+	crand.Read(v)
+	return math.Float64frombits(binary.BigEndian.Uint64(v))
+}
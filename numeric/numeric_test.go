@@ -0,0 +1,81 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package numeric_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kelindar/evolve"
+	"github.com/kelindar/evolve/numeric"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClone(t *testing.T) {
+	g := &numeric.Float64s{1, 2, 3}
+	clone := g.Clone()
+	assert.Equal(t, g, clone)
+	(*clone)[0]++
+	assert.NotEqual(t, g, clone)
+
+	c := numeric.NewChromosome(10, numeric.Operators[int]{})()
+	cc := c.Clone()
+	assert.Equal(t, c, cc)
+	cc.Vector[0]++
+	assert.NotEqual(t, c, cc)
+}
+
+func TestEvolveFloat64(t *testing.T) {
+	pop := evolve.New(256, func(g *numeric.Float64s) float32 {
+		return score(math.Abs((*g)[0] - 0.123456789))
+	}, numeric.NewVector[float64](1))
+
+	// Evolve
+	var last *numeric.Float64s
+	for i := 0; i < 1000; i++ {
+		last = pop.Evolve()
+	}
+
+	assert.InDelta(t, 0.123456789, (*last)[0], 0.001)
+	assert.NotEmpty(t, last.String())
+}
+
+func TestEvolveInts(t *testing.T) {
+	target := []int{42, -7, 1000}
+	pop := evolve.New(256, func(g *numeric.Ints) float32 {
+		var errors float64
+		for i, v := range *g {
+			errors += math.Abs(float64(v - target[i]))
+		}
+		return score(errors)
+	}, numeric.NewVector[int](len(target)))
+
+	// Evolve
+	var last *numeric.Ints
+	for i := 0; i < 2000; i++ {
+		if last = pop.Evolve(); (*last)[0] == 42 && (*last)[1] == -7 && (*last)[2] == 1000 {
+			break
+		}
+	}
+
+	assert.Equal(t, numeric.Ints(target), *last)
+}
+
+func TestNamedType(t *testing.T) {
+	type meters float64
+	g := numeric.NewVector[meters](10)()
+	assert.Len(t, *g, 10)
+
+	g.Crossover(g, numeric.NewVector[meters](10)())
+	g.Mutate()
+	assert.Len(t, *g, 10)
+}
+
+// score inverts an error, so the highest score will be with fewer errors
+func score(errors float64) float32 {
+	if math.IsNaN(errors) || math.IsInf(errors, 0) {
+		return 0
+	}
+	return float32(1 / (1 + errors))
+}
@@ -0,0 +1,158 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package numeric
+
+import (
+	mrand "math/rand"
+
+	"github.com/kelindar/evolve/internal/operator"
+	"github.com/kelindar/evolve/internal/sequence"
+)
+
+// Crossover represents a crossover operator which writes the offspring of two parents into dst
+type Crossover[T Number] func(dst, p1, p2 *Vector[T])
+
+// Mutation represents a mutation operator which mutates the genome in-place
+type Mutation[T Number] func(g *Vector[T])
+
+// Operators represents a set of genetic operators for a numeric genome. When one of the
+// operators is not specified, the default operator of the genome is used.
+type Operators[T Number] struct {
+	Crossover Crossover[T] // The crossover operator
+	Mutation  Mutation[T]  // The mutation operator
+}
+
+// ---------------------------------- Chromosome ----------------------------------
+
+// Chromosome represents a numeric genome with configurable operators
+type Chromosome[T Number] struct {
+	Vector[T]
+	ops   *Operators[T]
+	trace []string // The names of the operators, shared between the chromosomes
+}
+
+// NewChromosome creates a function for a random chromosome with the specified operators
+func NewChromosome[T Number](length int, ops Operators[T]) func() *Chromosome[T] {
+	genesis := NewVector[T](length)
+	trace := []string{operator.Name(ops.Crossover, "crossover"), operator.Name(ops.Mutation, "mutation")}
+	return func() *Chromosome[T] {
+		return &Chromosome[T]{
+			Vector: *genesis(),
+			ops:    &ops,
+			trace:  trace,
+		}
+	}
+}
+
+// Crossover performs the crossover using the configured operator
+func (c *Chromosome[T]) Crossover(c1, c2 *Chromosome[T]) {
+	if c.ops.Crossover == nil {
+		blend(&c.Vector, &c1.Vector, &c2.Vector)
+		return
+	}
+
+	c.ops.Crossover(&c.Vector, &c1.Vector, &c2.Vector)
+}
+
+// Mutate performs the mutation using the configured operator
+func (c *Chromosome[T]) Mutate() {
+	if c.ops.Mutation == nil {
+		c.Vector.Mutate()
+		return
+	}
+
+	c.ops.Mutation(&c.Vector)
+}
+
+// Operators returns the names of the crossover and mutation operators applied to the
+// chromosome, which are recorded in the lineage of the population.
+func (c *Chromosome[T]) Operators() []string {
+	return c.trace
+}
+
+// Reset resets the internal state, no-op in this case
+func (c *Chromosome[T]) Reset() {
+	// No state
+}
+
+// Clone returns a copy of the chromosome, sharing the same operators
+func (c *Chromosome[T]) Clone() *Chromosome[T] {
+	return &Chromosome[T]{
+		Vector: *c.Vector.Clone(),
+		ops:    c.ops,
+		trace:  c.trace,
+	}
+}
+
+// String implement stringer interface
+func (c *Chromosome[T]) String() string {
+	if c == nil {
+		return "<nil>"
+	}
+
+	return c.Vector.String()
+}
+
+// ---------------------------------- Operators ----------------------------------
+
+// Blend creates the default crossover, which moves every gene of the first parent slightly
+// towards the corresponding gene of the second parent.
+func Blend[T Number]() Crossover[T] {
+	return blend[T]
+}
+
+// Splice creates a cut-and-splice crossover for variable-length genomes, which joins the head
+// of the first parent with the tail of the second parent at independent cut points. The length
+// of the offspring is kept within the [min, max] range, where a maximum of zero is unbounded.
+func Splice[T Number](min, max int) Crossover[T] {
+	return func(dst, p1, p2 *Vector[T]) {
+		*dst = sequence.Splice(*dst, *p1, *p2, min, max)
+	}
+}
+
+// Replace creates the default mutation, which replaces a random gene with the specified rate
+func Replace[T Number](rate float64) Mutation[T] {
+	return func(g *Vector[T]) {
+		if len(*g) == 0 || mrand.Float64() >= rate {
+			return
+		}
+
+		i := mrand.Intn(len(*g))
+		(*g)[i] = mutate((*g)[i])
+	}
+}
+
+// Insertion creates a mutation for variable-length genomes, which inserts a random gene at a
+// random position with the specified rate, as long as the length stays under the maximum. A
+// maximum of zero is unbounded.
+func Insertion[T Number](rate float64, max int) Mutation[T] {
+	return func(g *Vector[T]) {
+		if (max > 0 && len(*g) >= max) || mrand.Float64() >= rate {
+			return
+		}
+
+		*g = sequence.Insert(*g, mrand.Intn(len(*g)+1), randValue[T]())
+	}
+}
+
+// Deletion creates a mutation for variable-length genomes, which deletes a random gene with
+// the specified rate, as long as the length stays above the minimum.
+func Deletion[T Number](rate float64, min int) Mutation[T] {
+	return func(g *Vector[T]) {
+		if len(*g) <= min || len(*g) == 0 || mrand.Float64() >= rate {
+			return
+		}
+
+		*g = sequence.Delete(*g, mrand.Intn(len(*g)))
+	}
+}
+
+// Chain creates a mutation which applies all of the specified mutations in order
+func Chain[T Number](mutations ...Mutation[T]) Mutation[T] {
+	return func(g *Vector[T]) {
+		for _, mutate := range mutations {
+			mutate(g)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package numeric_test
+
+import (
+	"testing"
+
+	"github.com/kelindar/evolve"
+	"github.com/kelindar/evolve/numeric"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDifferentLengths(t *testing.T) {
+	long := numeric.Ints{1, 1, 1, 1, 1}
+	short := numeric.Ints{1, 1}
+
+	dst := numeric.Ints{}
+	dst.Crossover(&long, &short)
+	assert.Equal(t, long, dst)
+
+	dst.Crossover(&short, &long)
+	assert.Equal(t, short, dst)
+}
+
+func TestSplice(t *testing.T) {
+	p1 := numeric.Ints{1, 1, 1, 1, 1, 1}
+	p2 := numeric.Ints{2, 2, 2, 2, 2, 2, 2, 2}
+	for i := 0; i < 100; i++ {
+		var dst numeric.Ints
+		numeric.Splice[int](3, 7)(&dst, &p1, &p2)
+		assert.GreaterOrEqual(t, len(dst), 3)
+		assert.LessOrEqual(t, len(dst), 7)
+	}
+}
+
+func TestInsertionDeletion(t *testing.T) {
+	g := numeric.Float64s{1, 2, 3}
+	mutate := numeric.Chain(
+		numeric.Insertion[float64](1, 5),
+		numeric.Insertion[float64](1, 5),
+		numeric.Insertion[float64](1, 5),
+	)
+
+	mutate(&g)
+	assert.Len(t, g, 5)
+
+	numeric.Chain(
+		numeric.Deletion[float64](1, 4),
+		numeric.Deletion[float64](1, 4),
+		numeric.Replace[float64](1),
+	)(&g)
+	assert.Len(t, g, 4)
+
+	// A maximum of zero is unbounded
+	numeric.Insertion[float64](1, 0)(&g)
+	assert.Len(t, g, 5)
+}
+
+func TestVariableLength(t *testing.T) {
+	target := []int{5, 4, 3, 2, 1, 0}
+	pop := evolve.New(256, func(c *numeric.Chromosome[int]) float32 {
+		score := float32(len(target) * 2)
+		for i := 0; i < len(c.Vector) || i < len(target); i++ {
+			if i >= len(c.Vector) || i >= len(target) || c.Vector[i] != target[i] {
+				score--
+			}
+		}
+		if score < 0 {
+			return 0
+		}
+		return score
+	}, numeric.NewChromosome(2, numeric.Operators[int]{
+		Crossover: numeric.Splice[int](1, 10),
+		Mutation: numeric.Chain(
+			numeric.Replace[int](0.2),
+			numeric.Insertion[int](0.05, 10),
+			numeric.Deletion[int](0.05, 1),
+		),
+	}))
+
+	// Seed the genes with small integers, since the target is small
+	pop.Range(func(c *numeric.Chromosome[int], _ float32) {
+		for i := range c.Vector {
+			c.Vector[i] = i
+		}
+	})
+
+	var last *numeric.Chromosome[int]
+	for i := 0; i < 5000; i++ {
+		if last = pop.Evolve(); assert.ObjectsAreEqual(numeric.Ints(target), last.Vector) {
+			break
+		}
+	}
+
+	assert.Equal(t, numeric.Ints(target), last.Vector)
+	assert.NotEmpty(t, last.String())
+}
+
+func TestChromosomeDefault(t *testing.T) {
+	genesis := numeric.NewChromosome(4, numeric.Operators[float32]{})
+	c := genesis()
+	c.Crossover(genesis(), genesis())
+	c.Mutate()
+	c.Reset()
+	assert.Len(t, c.Vector, 4)
+
+	c = numeric.NewChromosome(4, numeric.Operators[float32]{
+		Crossover: numeric.Blend[float32](),
+	})()
+	c.Crossover(genesis(), genesis())
+	assert.Len(t, c.Vector, 4)
+}
@@ -0,0 +1,26 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package permutation
+
+// Distance returns the number of positions which hold different values in the two genomes.
+// If the lengths are different, every missing position is counted as a difference.
+func (g *Genome) Distance(other *Genome) float64 {
+	v1, v2 := *g, *other
+	if len(v1) > len(v2) {
+		v1, v2 = v2, v1
+	}
+
+	distance := len(v2) - len(v1)
+	for i, v := range v1 {
+		if v != v2[i] {
+			distance++
+		}
+	}
+	return float64(distance)
+}
+
+// Distance returns the number of positions which hold different values in the two chromosomes
+func (c *Chromosome) Distance(other *Chromosome) float64 {
+	return c.Genome.Distance(&other.Genome)
+}
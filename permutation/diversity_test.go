@@ -0,0 +1,17 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package permutation_test
+
+import (
+	"testing"
+
+	"github.com/kelindar/evolve/permutation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistance(t *testing.T) {
+	g1, g2 := permutation.Genome{0, 1, 2, 3}, permutation.Genome{0, 2, 1, 3, 4}
+	assert.Equal(t, 3.0, g1.Distance(&g2))
+	assert.Equal(t, 0.0, g1.Distance(&g1))
+}
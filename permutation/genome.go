@@ -0,0 +1,68 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package permutation
+
+import (
+	"fmt"
+	mrand "math/rand"
+)
+
+// Genome represents a permutation genome of the integers in [0, n) range, where
+// the order of the values is what is being evolved.
+type Genome []int
+
+// New creates a function for a random permutation of the specified length
+func New(length int) func() *Genome {
+	return func() *Genome {
+		v := Genome(mrand.Perm(length))
+		return &v
+	}
+}
+
+// Crossover implements an order crossover (OX)
+func (g *Genome) Crossover(p1, p2 *Genome) {
+	orderCrossover(g, p1, p2)
+}
+
+// Mutate reverses a random segment of the permutation
+func (g *Genome) Mutate() {
+	const rate = 0.10
+	if mrand.Float32() >= rate {
+		return
+	}
+
+	inversion(*g)
+}
+
+// Valid checks whether the genome is a valid permutation of the integers in [0, n) range
+func (g *Genome) Valid() bool {
+	seen := make([]bool, len(*g))
+	for _, v := range *g {
+		if v < 0 || v >= len(seen) || seen[v] {
+			return false
+		}
+		seen[v] = true
+	}
+	return true
+}
+
+// String implement stringer interface
+func (g *Genome) String() string {
+	if g == nil {
+		return "<nil>"
+	}
+
+	return fmt.Sprintf("%v", *g)
+}
+
+// Reset resets the internal state, no-op in this case
+func (g *Genome) Reset() {
+	// No state
+}
+
+// Clone returns a copy of the genome
+func (g *Genome) Clone() *Genome {
+	clone := append(Genome(nil), *g...)
+	return &clone
+}
@@ -0,0 +1,79 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package permutation_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kelindar/evolve"
+	"github.com/kelindar/evolve/permutation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvolve(t *testing.T) {
+	const cities = 12
+	pop := evolve.New(256, fitnessFor(cities), permutation.New(cities))
+
+	// Evolve
+	var last *permutation.Genome
+	for i := 0; i < 1000; i++ {
+		last = pop.Evolve()
+	}
+
+	assert.True(t, last.Valid())
+	assert.InDelta(t, optimal(cities), distanceOf(*last), 0.001)
+	assert.NotEmpty(t, last.String())
+}
+
+func TestClone(t *testing.T) {
+	g := permutation.New(10)()
+	clone := g.Clone()
+	assert.Equal(t, g, clone)
+	(*clone)[0], (*clone)[1] = (*clone)[1], (*clone)[0]
+	assert.NotEqual(t, g, clone)
+
+	c := permutation.NewChromosome(10, permutation.Operators{})()
+	cc := c.Clone()
+	assert.Equal(t, c, cc)
+	cc.Genome[0], cc.Genome[1] = cc.Genome[1], cc.Genome[0]
+	assert.NotEqual(t, c, cc)
+}
+
+func TestValid(t *testing.T) {
+	assert.True(t, permutation.New(10)().Valid())
+	assert.False(t, (&permutation.Genome{0, 1, 1}).Valid())
+	assert.False(t, (&permutation.Genome{0, 1, 3}).Valid())
+	assert.False(t, (&permutation.Genome{-1, 0, 1}).Valid())
+}
+
+// fitnessFor returns the fitness function of a travelling salesman problem, where the
+// cities are placed on a circle and the shortest tour is going around it.
+func fitnessFor(cities int) func(*permutation.Genome) float32 {
+	best := optimal(cities)
+	return func(g *permutation.Genome) float32 {
+		return float32(best / distanceOf(*g))
+	}
+}
+
+// distanceOf computes the length of the tour
+func distanceOf(tour []int) (distance float64) {
+	n := float64(len(tour))
+	for i, city := range tour {
+		next := tour[(i+1)%len(tour)]
+		a1 := 2 * math.Pi * float64(city) / n
+		a2 := 2 * math.Pi * float64(next) / n
+		distance += math.Hypot(math.Cos(a1)-math.Cos(a2), math.Sin(a1)-math.Sin(a2))
+	}
+	return
+}
+
+// optimal returns the length of the optimal tour
+func optimal(cities int) float64 {
+	tour := make([]int, cities)
+	for i := range tour {
+		tour[i] = i
+	}
+	return distanceOf(tour)
+}
@@ -0,0 +1,400 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package permutation
+
+import (
+	mrand "math/rand"
+
+	"github.com/kelindar/evolve/internal/operator"
+	"github.com/kelindar/evolve/internal/sequence"
+)
+
+// Crossover represents a crossover operator which writes the offspring of two parents into dst
+type Crossover func(dst, p1, p2 *Genome)
+
+// Mutation represents a mutation operator which mutates the genome in-place
+type Mutation func(g *Genome)
+
+// Operators represents a set of genetic operators for a permutation genome. When one of
+// the operators is not specified, the default operator of the genome is used. All of the
+// operators always produce a valid permutation.
+type Operators struct {
+	Crossover Crossover // The crossover operator
+	Mutation  Mutation  // The mutation operator
+}
+
+// ---------------------------------- Chromosome ----------------------------------
+
+// Chromosome represents a permutation genome with configurable operators
+type Chromosome struct {
+	Genome
+	ops   *Operators
+	trace []string // The names of the operators, shared between the chromosomes
+}
+
+// NewChromosome creates a function for a random chromosome with the specified operators
+func NewChromosome(length int, ops Operators) func() *Chromosome {
+	trace := []string{operator.Name(ops.Crossover, "crossover"), operator.Name(ops.Mutation, "mutation")}
+	return func() *Chromosome {
+		return &Chromosome{
+			Genome: Genome(mrand.Perm(length)),
+			ops:    &ops,
+			trace:  trace,
+		}
+	}
+}
+
+// Crossover performs the crossover using the configured operator
+func (c *Chromosome) Crossover(c1, c2 *Chromosome) {
+	if c.ops.Crossover == nil {
+		c.Genome.Crossover(&c1.Genome, &c2.Genome)
+		return
+	}
+
+	c.ops.Crossover(&c.Genome, &c1.Genome, &c2.Genome)
+}
+
+// Mutate performs the mutation using the configured operator
+func (c *Chromosome) Mutate() {
+	if c.ops.Mutation == nil {
+		c.Genome.Mutate()
+		return
+	}
+
+	c.ops.Mutation(&c.Genome)
+}
+
+// Operators returns the names of the crossover and mutation operators applied to the
+// chromosome, which are recorded in the lineage of the population.
+func (c *Chromosome) Operators() []string {
+	return c.trace
+}
+
+// Reset resets the internal state, no-op in this case
+func (c *Chromosome) Reset() {
+	// No state
+}
+
+// Clone returns a copy of the chromosome, sharing the same operators
+func (c *Chromosome) Clone() *Chromosome {
+	return &Chromosome{
+		Genome: *c.Genome.Clone(),
+		ops:    c.ops,
+		trace:  c.trace,
+	}
+}
+
+// String implement stringer interface
+func (c *Chromosome) String() string {
+	if c == nil {
+		return "<nil>"
+	}
+
+	return c.Genome.String()
+}
+
+// ---------------------------------- Crossover ----------------------------------
+
+// Order creates an order crossover (OX), which copies a random segment of the first parent
+// and fills in the remaining values in the order they appear in the second parent.
+func Order() Crossover {
+	return orderCrossover
+}
+
+// PMX creates a partially mapped crossover, which copies a random segment of the first parent
+// and places the values of the second parent by following the mapping defined by the segment.
+func PMX() Crossover {
+	return func(dst, p1, p2 *Genome) {
+		v1, v2 := *p1, *p2
+		n := len(v1)
+		child := resize(dst, n)
+		if n < 2 {
+			copy(child, v1)
+			return
+		}
+
+		// Copy the segment from the first parent
+		i, j := randSegment(n)
+		inSegment := make([]bool, n)
+		indexOf := make([]int, n)
+		for k := range child {
+			child[k] = -1
+			indexOf[v2[k]] = k
+		}
+		for k := i; k < j; k++ {
+			child[k] = v1[k]
+			inSegment[v1[k]] = true
+		}
+
+		// Place the values of the second parent's segment which were not copied yet
+		for k := i; k < j; k++ {
+			v := v2[k]
+			if inSegment[v] {
+				continue
+			}
+
+			pos := k
+			for pos >= i && pos < j {
+				pos = indexOf[v1[pos]]
+			}
+			child[pos] = v
+		}
+
+		// Fill the rest from the second parent
+		for k, v := range child {
+			if v < 0 {
+				child[k] = v2[k]
+			}
+		}
+	}
+}
+
+// Cycle creates a cycle crossover (CX), which partitions the positions into cycles and
+// alternates the parent every cycle is taken from, so every value keeps a parent's position.
+func Cycle() Crossover {
+	return func(dst, p1, p2 *Genome) {
+		v1, v2 := *p1, *p2
+		n := len(v1)
+		child := resize(dst, n)
+		indexOf := make([]int, n)
+		visited := make([]bool, n)
+		for k, v := range v1 {
+			indexOf[v] = k
+		}
+
+		for start, parent := 0, 0; start < n; start++ {
+			if visited[start] {
+				continue
+			}
+
+			// Follow the cycle and copy it from the current parent
+			src := v1
+			if parent%2 == 1 {
+				src = v2
+			}
+
+			for pos := start; !visited[pos]; pos = indexOf[v2[pos]] {
+				visited[pos] = true
+				child[pos] = src[pos]
+			}
+			parent++
+		}
+	}
+}
+
+// Edge creates an edge recombination crossover (ERX), which builds the offspring from the
+// adjacency of the values in both parents and preserves as many of their edges as possible.
+func Edge() Crossover {
+	return func(dst, p1, p2 *Genome) {
+		v1, v2 := *p1, *p2
+		n := len(v1)
+		child := resize(dst, n)
+		if n == 0 {
+			return
+		}
+
+		// Build the edge table from both of the parents
+		edges := make([][4]int, n)
+		counts := make([]int, n)
+		for _, parent := range []Genome{v1, v2} {
+			for k, v := range parent {
+				addEdge(edges, counts, v, parent[(k+n-1)%n])
+				addEdge(edges, counts, v, parent[(k+1)%n])
+			}
+		}
+
+		used := make([]bool, n)
+		current := v1[0]
+		for k := 0; k < n; k++ {
+			child[k] = current
+			used[current] = true
+
+			// Remove the current value from the neighbours' edge lists
+			for e := 0; e < counts[current]; e++ {
+				removeEdge(edges, counts, edges[current][e], current)
+			}
+
+			if k == n-1 {
+				break
+			}
+
+			// Pick the neighbour with the fewest edges, or a random unused value
+			next, best, ties := -1, 5, 0
+			for e := 0; e < counts[current]; e++ {
+				switch neighbour := edges[current][e]; {
+				case counts[neighbour] < best:
+					next, best, ties = neighbour, counts[neighbour], 1
+				case counts[neighbour] == best:
+					if ties++; mrand.Intn(ties) == 0 {
+						next = neighbour
+					}
+				}
+			}
+
+			if next < 0 {
+				next = randUnused(used, n-k-1)
+			}
+			current = next
+		}
+	}
+}
+
+// ---------------------------------- Mutation ----------------------------------
+
+// Swap creates a mutation which swaps two random values with the specified rate
+func Swap(rate float64) Mutation {
+	return func(g *Genome) {
+		if len(*g) < 2 || mrand.Float64() >= rate {
+			return
+		}
+
+		v := *g
+		i, j := mrand.Intn(len(v)), mrand.Intn(len(v))
+		v[i], v[j] = v[j], v[i]
+	}
+}
+
+// Insert creates a mutation which moves a random value to a random position with the
+// specified rate, shifting the values in between.
+func Insert(rate float64) Mutation {
+	return func(g *Genome) {
+		if len(*g) < 2 || mrand.Float64() >= rate {
+			return
+		}
+
+		v := *g
+		from, to := mrand.Intn(len(v)), mrand.Intn(len(v))
+		value := v[from]
+		switch {
+		case from < to:
+			copy(v[from:to], v[from+1:to+1])
+		case from > to:
+			copy(v[to+1:from+1], v[to:from])
+		}
+		v[to] = value
+	}
+}
+
+// Inversion creates a mutation which reverses a random segment with the specified rate
+func Inversion(rate float64) Mutation {
+	return func(g *Genome) {
+		if mrand.Float64() < rate {
+			inversion(*g)
+		}
+	}
+}
+
+// Scramble creates a mutation which shuffles a random segment with the specified rate
+func Scramble(rate float64) Mutation {
+	return func(g *Genome) {
+		if len(*g) < 2 || mrand.Float64() >= rate {
+			return
+		}
+
+		i, j := randSegment(len(*g))
+		segment := (*g)[i:j]
+		mrand.Shuffle(len(segment), func(a, b int) {
+			segment[a], segment[b] = segment[b], segment[a]
+		})
+	}
+}
+
+// ---------------------------------- Helpers ----------------------------------
+
+// orderCrossover implements an order crossover (OX)
+func orderCrossover(dst, p1, p2 *Genome) {
+	v1, v2 := *p1, *p2
+	n := len(v1)
+	child := resize(dst, n)
+	if n < 2 {
+		copy(child, v1)
+		return
+	}
+
+	// Copy the segment from the first parent
+	i, j := randSegment(n)
+	used := make([]bool, n)
+	for k := i; k < j; k++ {
+		child[k] = v1[k]
+		used[v1[k]] = true
+	}
+
+	// Fill in the rest in the order of the second parent, starting after the segment
+	pos := j % n
+	for k := 0; k < n; k++ {
+		if v := v2[(j+k)%n]; !used[v] {
+			child[pos] = v
+			pos = (pos + 1) % n
+		}
+	}
+}
+
+// inversion reverses a random segment of the permutation
+func inversion(v Genome) {
+	if len(v) < 2 {
+		return
+	}
+
+	for i, j := randSegment(len(v)); i < j-1; i, j = i+1, j-1 {
+		v[i], v[j-1] = v[j-1], v[i]
+	}
+}
+
+// randSegment returns a random non-empty [i, j) segment
+func randSegment(n int) (int, int) {
+	i, j := mrand.Intn(n), mrand.Intn(n)
+	if i > j {
+		i, j = j, i
+	}
+	return i, j + 1
+}
+
+// randUnused picks a random unused value, given the number of unused values remaining
+func randUnused(used []bool, remaining int) int {
+	skip := mrand.Intn(remaining)
+	for v, ok := range used {
+		if ok {
+			continue
+		}
+
+		if skip == 0 {
+			return v
+		}
+		skip--
+	}
+	return -1
+}
+
+// addEdge adds an edge to the edge table, unless it is already present
+func addEdge(edges [][4]int, counts []int, from, to int) {
+	if from == to {
+		return
+	}
+
+	for e := 0; e < counts[from]; e++ {
+		if edges[from][e] == to {
+			return
+		}
+	}
+
+	edges[from][counts[from]] = to
+	counts[from]++
+}
+
+// removeEdge removes an edge from the edge table
+func removeEdge(edges [][4]int, counts []int, from, to int) {
+	for e := 0; e < counts[from]; e++ {
+		if edges[from][e] == to {
+			counts[from]--
+			edges[from][e] = edges[from][counts[from]]
+			return
+		}
+	}
+}
+
+// resize resizes the genome to the specified length, reusing its capacity if possible
+func resize(g *Genome, length int) Genome {
+	*g = sequence.Resize(*g, length)
+	return *g
+}
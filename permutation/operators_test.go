@@ -0,0 +1,160 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package permutation_test
+
+import (
+	"testing"
+
+	"github.com/kelindar/evolve"
+	"github.com/kelindar/evolve/permutation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrossoverValid(t *testing.T) {
+	operators := map[string]permutation.Crossover{
+		"order": permutation.Order(),
+		"pmx":   permutation.PMX(),
+		"cycle": permutation.Cycle(),
+		"edge":  permutation.Edge(),
+	}
+
+	for name, crossover := range operators {
+		t.Run(name, func(t *testing.T) {
+			for _, n := range []int{0, 1, 2, 3, 10, 50} {
+				for i := 0; i < 100; i++ {
+					var child permutation.Genome
+					crossover(&child, permutation.New(n)(), permutation.New(n)())
+					assert.Len(t, child, n)
+					assert.True(t, child.Valid(), "%v", child)
+				}
+			}
+		})
+	}
+}
+
+func TestCrossoverIdentical(t *testing.T) {
+	for _, crossover := range []permutation.Crossover{
+		permutation.Order(),
+		permutation.PMX(),
+		permutation.Cycle(),
+	} {
+		p := permutation.Genome{3, 1, 4, 0, 2, 5}
+		var child permutation.Genome
+		crossover(&child, &p, &p)
+		assert.Equal(t, p, child)
+	}
+}
+
+func TestEdge(t *testing.T) {
+	p := permutation.New(30)()
+	var child permutation.Genome
+	permutation.Edge()(&child, p, p)
+
+	// The tour might be reversed, but every edge of the parent must be preserved
+	assert.Equal(t, 30, len(child))
+	assert.Equal(t, (*p)[0], child[0])
+	for k, v := range child {
+		next := child[(k+1)%len(child)]
+		assert.Contains(t, []int{next, child[(k+len(child)-1)%len(child)]}, neighbourOf(*p, v, 1))
+		assert.Contains(t, []int{next, child[(k+len(child)-1)%len(child)]}, neighbourOf(*p, v, -1))
+	}
+}
+
+func TestCycle(t *testing.T) {
+	p1 := permutation.Genome{0, 1, 2, 3, 4, 5, 6, 7}
+	p2 := permutation.Genome{1, 0, 3, 2, 5, 4, 7, 6}
+
+	var child permutation.Genome
+	permutation.Cycle()(&child, &p1, &p2)
+	assert.Equal(t, permutation.Genome{0, 1, 3, 2, 4, 5, 7, 6}, child)
+}
+
+func TestPMX(t *testing.T) {
+	p1 := permutation.New(20)()
+	p2 := permutation.New(20)()
+
+	// Every value of the child keeps the position from one of the parents, unless it was
+	// displaced by the mapping of the segment
+	for i := 0; i < 100; i++ {
+		var child permutation.Genome
+		permutation.PMX()(&child, p1, p2)
+		matches := 0
+		for k, v := range child {
+			if v == (*p1)[k] || v == (*p2)[k] {
+				matches++
+			}
+		}
+		assert.Greater(t, matches, 0)
+	}
+}
+
+// neighbourOf returns the neighbour of a value in a circular tour
+func neighbourOf(tour []int, value, direction int) int {
+	for k, v := range tour {
+		if v == value {
+			return tour[(k+direction+len(tour))%len(tour)]
+		}
+	}
+	return -1
+}
+
+func TestMutationValid(t *testing.T) {
+	operators := map[string]permutation.Mutation{
+		"swap":      permutation.Swap(1),
+		"insert":    permutation.Insert(1),
+		"inversion": permutation.Inversion(1),
+		"scramble":  permutation.Scramble(1),
+	}
+
+	for name, mutate := range operators {
+		t.Run(name, func(t *testing.T) {
+			for _, n := range []int{0, 1, 2, 3, 10, 50} {
+				g := permutation.New(n)()
+				for i := 0; i < 100; i++ {
+					mutate(g)
+					assert.Len(t, *g, n)
+					assert.True(t, g.Valid(), "%v", *g)
+				}
+			}
+		})
+	}
+}
+
+func TestInsert(t *testing.T) {
+	g := permutation.New(10)()
+	before := append(permutation.Genome(nil), *g...)
+	permutation.Insert(0)(g)
+	assert.Equal(t, before, *g)
+
+	changed := false
+	for i := 0; i < 10 && !changed; i++ {
+		permutation.Insert(1)(g)
+		changed = !assert.ObjectsAreEqual(before, *g)
+	}
+	assert.True(t, changed)
+}
+
+func TestChromosome(t *testing.T) {
+	const cities = 12
+	fit := fitnessFor(cities)
+	for _, ops := range []permutation.Operators{
+		{Crossover: permutation.Order(), Mutation: permutation.Inversion(0.2)},
+		{Crossover: permutation.PMX(), Mutation: permutation.Insert(0.2)},
+		{Crossover: permutation.Edge(), Mutation: permutation.Swap(0.2)},
+		{},
+	} {
+		pop := evolve.New(256, func(c *permutation.Chromosome) float32 {
+			return fit(&c.Genome)
+		}, permutation.NewChromosome(cities, ops))
+
+		var last *permutation.Chromosome
+		for i := 0; i < 1000; i++ {
+			last = pop.Evolve()
+		}
+
+		last.Reset()
+		assert.True(t, last.Valid())
+		assert.InDelta(t, optimal(cities), distanceOf(last.Genome), 0.001, last.String())
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package evolve
+
+import (
+	"fmt"
+	"math"
+)
+
+// Strategy represents a restart strategy
+type Strategy int
+
+const (
+	// FullRestart re-initializes the population with random genomes, except for the fittest
+	// genomes which are kept. The population can optionally grow on every restart.
+	FullRestart Strategy = iota
+
+	// PartialRestart re-initializes the worst fraction of the population with random genomes,
+	// also known as random immigrants.
+	PartialRestart
+
+	// Hypermutation mutates the population repeatedly, except for the fittest genomes which
+	// are kept, in order to scatter it around the region it converged to.
+	Hypermutation
+)
+
+// Restart represents a restart policy, which is triggered when the population stagnates. This
+// is detected either when the best fitness does not improve for a number of generations, or
+// when the diversity of the fitness (its coefficient of variation) drops below a threshold.
+type Restart struct {
+	Strategy   Strategy // The restart strategy
+	Stagnation int      // The number of generations without improvement that triggers a restart
+	Diversity  float64  // The fitness coefficient of variation below which a restart is triggered
+	Keep       int      // The number of fittest genomes kept by a full restart or hypermutation (default: 1)
+	Fraction   float64  // The fraction of the worst genomes re-initialized by a partial restart (default: 0.5)
+	Burst      int      // The number of mutations applied to every genome by hypermutation (default: 10)
+	Growth     float64  // The multiplier of the population size on a full restart, as in IPOP (default: 1)
+}
+
+// stagnation tracks the progress of the population for the restart policy
+type stagnation struct {
+	best        float32 // The best fitness so far
+	generations int     // The number of generations without improvement
+	restarts    int     // The number of restarts so far
+}
+
+// SetRestart sets the restart policy of the population, which is checked after every
+// evaluation. When the policy triggers, the population is restarted and evaluated again.
+func (p *Population[T]) SetRestart(policy Restart) {
+	switch {
+	case policy.Stagnation <= 0 && policy.Diversity <= 0:
+		panic(fmt.Errorf("evolve: restart requires either a stagnation or a diversity trigger"))
+	case policy.Fraction < 0 || policy.Fraction > 1:
+		panic(fmt.Errorf("evolve: restart fraction must be within [0, 1], got %v", policy.Fraction))
+	case policy.Growth < 0:
+		panic(fmt.Errorf("evolve: restart growth must not be negative, got %v", policy.Growth))
+	}
+
+	if policy.Keep <= 0 {
+		policy.Keep = 1
+	}
+	if policy.Fraction == 0 {
+		policy.Fraction = 0.5
+	}
+	if policy.Burst <= 0 {
+		policy.Burst = 10
+	}
+	if policy.Growth == 0 {
+		policy.Growth = 1
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.policy = &policy
+	p.stagnation = stagnation{best: float32(math.Inf(-1))}
+}
+
+// Restarts returns the number of times the population was restarted
+func (p *Population[T]) Restarts() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.stagnation.restarts
+}
+
+// restart restarts the evaluated population if the restart policy is triggered, and returns
+// whether the population was restarted and hence needs to be evaluated again.
+func (p *Population[T]) restart() bool {
+	policy := p.policy
+	if policy == nil {
+		return false
+	}
+
+	// Track the improvement of the best fitness
+	rank := p.rank(len(p.genomes))
+	if best := p.fitness(rank[0]); best > p.stagnation.best {
+		p.stagnation.best = best
+		p.stagnation.generations = 0
+	} else {
+		p.stagnation.generations++
+	}
+
+	switch {
+	case policy.Stagnation > 0 && p.stagnation.generations >= policy.Stagnation:
+	case policy.Diversity > 0 && p.variation(len(p.genomes)) < policy.Diversity:
+	default:
+		return false
+	}
+
+	keep := policy.Keep
+	if keep > len(rank) {
+		keep = len(rank)
+	}
+
+	switch policy.Strategy {
+	case FullRestart:
+		elites := make([]T, 0, keep)
+		for _, i := range rank[:keep] {
+			elites = append(elites, p.genomes[i])
+		}
+
+		// Resize the population, making sure the elites still fit
+		size, prev := int(math.Round(float64(len(p.genomes))*policy.Growth)), len(p.genomes)
+		if size < keep {
+			size = keep
+		}
+
+		p.lineage.restart(rank[:keep], size, p.generation)
+		p.genomes = p.resize(p.genomes, size)
+		p.pools[p.pool] = p.genomes
+		for i := 0; i < size && i < prev; i++ {
+			if i < len(elites) {
+				p.genomes[i] = elites[i]
+				continue
+			}
+			p.genomes[i] = p.genesis()
+		}
+
+	case PartialRestart:
+		n := int(math.Ceil(float64(len(rank)) * policy.Fraction))
+		p.reinitialize(rank[len(rank)-n:])
+
+	case Hypermutation:
+		for _, i := range rank[keep:] {
+			for k := 0; k < policy.Burst; k++ {
+				p.genomes[i].Mutate()
+			}
+			p.lineage.derive(i, p.generation, "hypermutation")
+		}
+	}
+
+	p.stagnation.generations = 0
+	p.stagnation.restarts++
+	return true
+}
+
+// reinitialize replaces the genomes at the specified indices with new random genomes
+func (p *Population[T]) reinitialize(indices []int) {
+	for _, i := range indices {
+		p.genomes[i] = p.genesis()
+		p.lineage.replace(i, Restarted, p.generation)
+	}
+}
+
+// variation computes the coefficient of variation of the fitness of the evaluated population
+func (p *Population[T]) variation(size int) float64 {
+	var sum, squares float64
+	for i := 0; i < size; i++ {
+		f := float64(p.fitness(i))
+		sum += f
+		squares += f * f
+	}
+
+	n := float64(size)
+	mean := sum / n
+	if mean == 0 {
+		return 0
+	}
+
+	variance := math.Max(squares/n-mean*mean, 0)
+	return math.Sqrt(variance) / math.Abs(mean)
+}
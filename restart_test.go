@@ -0,0 +1,99 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package evolve_test
+
+import (
+	"testing"
+
+	"github.com/kelindar/evolve"
+	"github.com/kelindar/evolve/binary"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestartFull(t *testing.T) {
+	pop := evolve.New(10, func(*binary.Genome) float32 {
+		return 1
+	}, binary.New(5))
+
+	pop.SetRestart(evolve.Restart{
+		Strategy:   evolve.FullRestart,
+		Stagnation: 3,
+		Growth:     2,
+	})
+
+	for i := 0; i < 8; i++ {
+		pop.Evolve()
+	}
+
+	assert.Equal(t, 2, pop.Restarts())
+	assert.Equal(t, 40, pop.Size())
+}
+
+func TestRestartPartial(t *testing.T) {
+	const target = "hello"
+	pop := newPop(100, target)
+	pop.SetRestart(evolve.Restart{
+		Strategy:  evolve.PartialRestart,
+		Diversity: 0.01,
+		Fraction:  0.2,
+	})
+
+	// Identical genomes have no diversity
+	genomes := make([]*binary.Genome, 100)
+	for i := range genomes {
+		g := binary.Genome("hallo")
+		genomes[i] = &g
+	}
+	pop.Seed(genomes...)
+
+	assert.Equal(t, "hallo", pop.Evolve().String())
+	assert.Equal(t, 1, pop.Restarts())
+	assert.Equal(t, 100, pop.Size())
+}
+
+func TestRestartHypermutation(t *testing.T) {
+	const target = "hello"
+	pop := newPop(100, target)
+	pop.SetRestart(evolve.Restart{
+		Strategy:   evolve.Hypermutation,
+		Stagnation: 1,
+		Keep:       2,
+		Burst:      500,
+	})
+
+	genomes := make([]*binary.Genome, 100)
+	for i := range genomes {
+		g := binary.Genome(target)
+		genomes[i] = &g
+	}
+
+	// The second generation stagnates, hence all but the elites are scattered
+	pop.Seed(genomes...)
+	pop.Evolve()
+	pop.Evolve()
+	assert.Equal(t, 1, pop.Restarts())
+
+	count := 0
+	for _, g := range pop.Top(100) {
+		if g.String() == target {
+			count++
+		}
+	}
+
+	assert.GreaterOrEqual(t, count, 2)
+	assert.Less(t, count, 10)
+}
+
+func TestRestartInvalid(t *testing.T) {
+	pop := newPop(10, "hello")
+	assert.Panics(t, func() {
+		pop.SetRestart(evolve.Restart{})
+	})
+	assert.Panics(t, func() {
+		pop.SetRestart(evolve.Restart{Stagnation: 1, Fraction: 2})
+	})
+	assert.Panics(t, func() {
+		pop.SetRestart(evolve.Restart{Stagnation: 1, Growth: -1})
+	})
+}
@@ -0,0 +1,362 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package tree
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Config represents the configuration of a tree genome
+type Config struct {
+	Functions    []Function // The function set
+	Terminals    []Terminal // The terminal set
+	MinDepth     int        // The minimum depth of the initial trees (default: 2)
+	MaxInitDepth int        // The maximum depth of the initial trees (default: 6)
+	MaxDepth     int        // The depth limit of any tree, to control bloat (default: 17)
+	MaxSize      int        // The size limit of any tree in nodes, to control bloat (default: none)
+	MutationRate float64    // The probability of mutating an offspring (default: 0.1)
+}
+
+// node represents a single primitive of the tree, stored in prefix order
+type node struct {
+	fn    int     // The index of the function, or -1 for terminals
+	term  int     // The index of the terminal
+	value float64 // The value of an ephemeral random constant
+}
+
+// Genome represents a program tree, encoded as a list of primitives in prefix order
+type Genome struct {
+	nodes []node    // The primitives of the tree
+	conf  *Config   // The shared configuration
+	stack []float64 // The scratch stack of the interpreter
+	args  []float64 // The scratch arguments of the interpreter
+}
+
+// New creates a function for random trees with the specified primitive set. Trees are
+// initialized using ramped half-and-half: the depth is ramped between the minimum and
+// maximum initial depth, and half of the trees are full while the other half are grown.
+func New(config Config) func() *Genome {
+	if len(config.Terminals) == 0 {
+		panic(fmt.Errorf("tree: at least one terminal is required"))
+	}
+
+	conf := config
+	conf.MinDepth = defaultOf(conf.MinDepth, 2)
+	conf.MaxInitDepth = defaultOf(conf.MaxInitDepth, 6)
+	conf.MaxDepth = defaultOf(conf.MaxDepth, 17)
+	if conf.MutationRate == 0 {
+		conf.MutationRate = 0.1
+	}
+
+	if conf.MinDepth > conf.MaxInitDepth {
+		panic(fmt.Errorf("tree: minimum depth %d exceeds the maximum initial depth %d", conf.MinDepth, conf.MaxInitDepth))
+	}
+
+	if conf.MaxInitDepth > conf.MaxDepth {
+		panic(fmt.Errorf("tree: maximum initial depth %d exceeds the maximum depth %d", conf.MaxInitDepth, conf.MaxDepth))
+	}
+
+	// The genesis may be called concurrently, for example when restarting a population
+	var counter atomic.Int64
+	return func() *Genome {
+		count := int(counter.Add(1) - 1)
+		ramp := conf.MaxInitDepth - conf.MinDepth + 1
+		depth := conf.MinDepth + (count/2)%ramp
+		full := count%2 == 0
+
+		g := &Genome{conf: &conf}
+		g.nodes = g.generate(g.nodes, depth, 1, full)
+		return g
+	}
+}
+
+// Size returns the number of nodes in the tree
+func (g *Genome) Size() int {
+	return len(g.nodes)
+}
+
+// Depth returns the depth of the tree, where a single terminal has a depth of zero
+func (g *Genome) Depth() int {
+	return depthOf(g.conf, g.nodes)
+}
+
+// Eval evaluates the program with the specified variables. The evaluation uses the scratch
+// space of the genome and must not be called concurrently on the same genome.
+func (g *Genome) Eval(vars []float64) float64 {
+	stack := g.stack[:0]
+	for i := len(g.nodes) - 1; i >= 0; i-- {
+		n := g.nodes[i]
+		if n.fn < 0 {
+			stack = append(stack, g.terminal(n, vars))
+			continue
+		}
+
+		// The arguments are on top of the stack, with the first argument on the top
+		fn := &g.conf.Functions[n.fn]
+		args := g.args[:0]
+		for k := 0; k < fn.Arity; k++ {
+			args = append(args, stack[len(stack)-1-k])
+		}
+
+		stack = append(stack[:len(stack)-fn.Arity], fn.Eval(args))
+		g.args = args
+	}
+
+	g.stack = stack
+	return stack[0]
+}
+
+// terminal returns the value of a terminal node
+func (g *Genome) terminal(n node, vars []float64) float64 {
+	t := &g.conf.Terminals[n.term]
+	switch {
+	case t.index >= 0:
+		return vars[t.index]
+	case t.random != nil:
+		return n.value
+	default:
+		return t.value
+	}
+}
+
+// Crossover performs a subtree crossover, replacing a random subtree of the first parent
+// with a random subtree of the second parent while respecting the size and depth limits.
+func (g *Genome) Crossover(t1, t2 *Genome) {
+	for attempt := 0; attempt < 5; attempt++ {
+		i, j := t1.point(), t2.point()
+		iEnd, jEnd := subtreeEnd(t1.conf, t1.nodes, i), subtreeEnd(t2.conf, t2.nodes, j)
+
+		// Build the offspring, by replacing the subtree
+		g.nodes = append(g.nodes[:0], t1.nodes[:i]...)
+		g.nodes = append(g.nodes, t2.nodes[j:jEnd]...)
+		g.nodes = append(g.nodes, t1.nodes[iEnd:]...)
+		if g.valid() {
+			return
+		}
+	}
+
+	// Failed to produce a valid offspring, clone the first parent
+	g.nodes = append(g.nodes[:0], t1.nodes...)
+}
+
+// Mutate performs either a point, a subtree or a hoist mutation
+func (g *Genome) Mutate() {
+	if rand.Float64() >= g.conf.MutationRate {
+		return
+	}
+
+	switch rand.Intn(3) {
+	case 0:
+		g.mutatePoint()
+	case 1:
+		g.mutateSubtree()
+	default:
+		g.mutateHoist()
+	}
+}
+
+// mutatePoint replaces a random node with a random primitive of the same arity
+func (g *Genome) mutatePoint() {
+	i := rand.Intn(len(g.nodes))
+	if n := &g.nodes[i]; n.fn < 0 {
+		*n = g.randTerminal()
+		return
+	}
+
+	// Pick one of the functions with the same arity
+	arity, count := g.conf.Functions[g.nodes[i].fn].Arity, 0
+	for k, fn := range g.conf.Functions {
+		if fn.Arity == arity {
+			if count++; rand.Intn(count) == 0 {
+				g.nodes[i].fn = k
+			}
+		}
+	}
+}
+
+// mutateSubtree replaces a random subtree with a newly grown one
+func (g *Genome) mutateSubtree() {
+	i := rand.Intn(len(g.nodes))
+	end := subtreeEnd(g.conf, g.nodes, i)
+
+	// Generate a new subtree and splice it in, keeping the old tree if it gets too large
+	grown := g.generate(nil, rand.Intn(4)+1, 0, false)
+	nodes := make([]node, 0, len(g.nodes)-(end-i)+len(grown))
+	nodes = append(nodes, g.nodes[:i]...)
+	nodes = append(nodes, grown...)
+	nodes = append(nodes, g.nodes[end:]...)
+	if g.fits(nodes) {
+		g.nodes = nodes
+	}
+}
+
+// mutateHoist replaces the tree with one of its own subtrees
+func (g *Genome) mutateHoist() {
+	i := g.point()
+	end := subtreeEnd(g.conf, g.nodes, i)
+	g.nodes = g.nodes[:copy(g.nodes, g.nodes[i:end])]
+}
+
+// Reset resets the internal state, no-op in this case
+func (g *Genome) Reset() {
+	// No state
+}
+
+// Clone returns a copy of the tree, sharing the same configuration
+func (g *Genome) Clone() *Genome {
+	return &Genome{
+		nodes: append([]node(nil), g.nodes...),
+		conf:  g.conf,
+	}
+}
+
+// String returns the tree as an s-expression
+func (g *Genome) String() string {
+	if g == nil {
+		return "<nil>"
+	}
+
+	var sb strings.Builder
+	g.format(&sb, 0)
+	return sb.String()
+}
+
+// format writes the subtree at the specified position and returns the position after it
+func (g *Genome) format(sb *strings.Builder, i int) int {
+	n := g.nodes[i]
+	if n.fn < 0 {
+		if t := &g.conf.Terminals[n.term]; t.random != nil {
+			sb.WriteString(strconv.FormatFloat(n.value, 'g', 4, 64))
+		} else {
+			sb.WriteString(t.Name)
+		}
+		return i + 1
+	}
+
+	fn := &g.conf.Functions[n.fn]
+	sb.WriteString("(")
+	sb.WriteString(fn.Name)
+	i++
+	for k := 0; k < fn.Arity; k++ {
+		sb.WriteString(" ")
+		i = g.format(sb, i)
+	}
+	sb.WriteString(")")
+	return i
+}
+
+// ---------------------------------- Generation ----------------------------------
+
+// generate appends a random tree of the specified depth, either full or grown. When grown,
+// the terminals are only placed after the specified minimum depth is reached.
+func (g *Genome) generate(dst []node, depth, min int, full bool) []node {
+	terminalRatio := float64(len(g.conf.Terminals)) / float64(len(g.conf.Terminals)+len(g.conf.Functions))
+	switch {
+	case depth == 0 || len(g.conf.Functions) == 0:
+		return append(dst, g.randTerminal())
+	case !full && min <= 0 && rand.Float64() < terminalRatio:
+		return append(dst, g.randTerminal())
+	}
+
+	fn := rand.Intn(len(g.conf.Functions))
+	dst = append(dst, node{fn: fn})
+	for k := 0; k < g.conf.Functions[fn].Arity; k++ {
+		dst = g.generate(dst, depth-1, min-1, full)
+	}
+	return dst
+}
+
+// randTerminal creates a random terminal node
+func (g *Genome) randTerminal() node {
+	i := rand.Intn(len(g.conf.Terminals))
+	n := node{fn: -1, term: i}
+	if t := &g.conf.Terminals[i]; t.random != nil {
+		n.value = t.random()
+	}
+	return n
+}
+
+// point selects a random crossover point, biased towards functions (90%) as per Koza
+func (g *Genome) point() int {
+	if len(g.nodes) == 1 || rand.Float64() >= 0.9 {
+		return rand.Intn(len(g.nodes))
+	}
+
+	for {
+		if i := rand.Intn(len(g.nodes)); g.nodes[i].fn >= 0 {
+			return i
+		}
+	}
+}
+
+// valid checks whether the tree respects the size and depth limits
+func (g *Genome) valid() bool {
+	return g.fits(g.nodes)
+}
+
+// fits checks whether the nodes respect the size and depth limits
+func (g *Genome) fits(nodes []node) bool {
+	if g.conf.MaxSize > 0 && len(nodes) > g.conf.MaxSize {
+		return false
+	}
+	return depthOf(g.conf, nodes) <= g.conf.MaxDepth
+}
+
+// subtreeEnd returns the position after the end of the subtree starting at i
+func subtreeEnd(conf *Config, nodes []node, i int) int {
+	for need := 1; need > 0; i++ {
+		need--
+		if fn := nodes[i].fn; fn >= 0 {
+			need += conf.Functions[fn].Arity
+		}
+	}
+	return i
+}
+
+// depthOf computes the depth of the tree encoded in prefix order
+func depthOf(conf *Config, nodes []node) (depth int) {
+	var remaining []int // The number of remaining children at every level
+	for _, n := range nodes {
+		if d := len(remaining); d > depth {
+			depth = d
+		}
+
+		if n.fn >= 0 && conf.Functions[n.fn].Arity > 0 {
+			remaining = append(remaining, conf.Functions[n.fn].Arity)
+			continue
+		}
+
+		// Pop every level where all of the children were visited
+		for len(remaining) > 0 {
+			if remaining[len(remaining)-1]--; remaining[len(remaining)-1] > 0 {
+				break
+			}
+			remaining = remaining[:len(remaining)-1]
+		}
+	}
+	return
+}
+
+// defaultOf returns the default value if the value is not set
+func defaultOf(value, defaultValue int) int {
+	if value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// Parsimony wraps the fitness function with a parsimony pressure, penalizing the fitness of
+// every tree proportionally to its size in order to control bloat.
+func Parsimony(fitness func(*Genome) float32, coefficient float32) func(*Genome) float32 {
+	return func(g *Genome) float32 {
+		if score := fitness(g) - coefficient*float32(g.Size()); score > 0 {
+			return score
+		}
+		return 0
+	}
+}
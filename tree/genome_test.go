@@ -0,0 +1,173 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package tree
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/kelindar/evolve"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvolve(t *testing.T) {
+	pop := evolve.New(512, Parsimony(evaluate, 0.001), New(Config{
+		Functions: []Function{Add, Sub, Mul, Div},
+		Terminals: []Terminal{Var("x", 0), Const(1)},
+		MaxDepth:  8,
+	}))
+
+	// Evolve
+	var last *Genome
+	for i := 0; i < 200; i++ {
+		if last = pop.Evolve(); evaluate(last) > 0.999 {
+			break
+		}
+	}
+
+	assert.Greater(t, evaluate(last), float32(0.999), last.String())
+}
+
+func TestRampedHalfAndHalf(t *testing.T) {
+	genesis := New(Config{
+		Functions:    []Function{Add, Neg},
+		Terminals:    []Terminal{Var("x", 0)},
+		MinDepth:     1,
+		MaxInitDepth: 4,
+	})
+
+	depths := map[int]int{}
+	for i := 0; i < 80; i++ {
+		g := genesis()
+		assert.Equal(t, len(g.nodes), subtreeEnd(g.conf, g.nodes, 0))
+		assert.LessOrEqual(t, g.Depth(), 4)
+		depths[g.Depth()]++
+
+		// Every even tree is full, at the ramped depth
+		if i%2 == 0 {
+			assert.Equal(t, 1+(i/2)%4, g.Depth())
+		}
+	}
+
+	assert.Len(t, depths, 4)
+
+	// The genesis can be called concurrently
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.LessOrEqual(t, genesis().Depth(), 4)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestEval(t *testing.T) {
+	g := &Genome{
+		conf: &Config{
+			Functions: []Function{Add, Mul, Div, If},
+			Terminals: []Terminal{Var("x", 0), Var("y", 1), Const(2), Ephemeral(Uniform(0, 1))},
+		},
+		nodes: []node{ // (add (mul x 2) (if y (div x y) 0.5))
+			{fn: 0},
+			{fn: 1}, {fn: -1, term: 0}, {fn: -1, term: 2},
+			{fn: 3}, {fn: -1, term: 1}, {fn: 2}, {fn: -1, term: 0}, {fn: -1, term: 1}, {fn: -1, term: 3, value: 0.5},
+		},
+	}
+
+	assert.Equal(t, "(add (mul x 2) (if y (div x y) 0.5))", g.String())
+	assert.Equal(t, 3, g.Depth())
+	assert.Equal(t, 10, g.Size())
+	assert.Equal(t, 3*2+3.0/2, g.Eval([]float64{3, 2}))
+	assert.Equal(t, 3*2+0.5, g.Eval([]float64{3, 0}))
+}
+
+func TestOperators(t *testing.T) {
+	genesis := New(Config{
+		Functions:    []Function{Add, Sub, Mul, Div, Sin, Cos, Exp, Log, Neg, If},
+		Terminals:    []Terminal{Var("x", 0), Ephemeral(Uniform(-1, 1))},
+		MaxDepth:     10,
+		MaxSize:      200,
+		MutationRate: 1,
+	})
+
+	child := genesis()
+	for i := 0; i < 1000; i++ {
+		child.Crossover(genesis(), genesis())
+		child.Mutate()
+		child.Reset()
+
+		assert.Equal(t, len(child.nodes), subtreeEnd(child.conf, child.nodes, 0))
+		assert.LessOrEqual(t, child.Depth(), 10)
+		assert.LessOrEqual(t, child.Size(), 200)
+		assert.NotPanics(t, func() {
+			child.Eval([]float64{0.5})
+		})
+	}
+}
+
+func TestClone(t *testing.T) {
+	g := New(Config{
+		Functions: []Function{Add, Mul},
+		Terminals: []Terminal{Var("x", 0), Ephemeral(Uniform(0, 1))},
+	})()
+
+	clone := g.Clone()
+	assert.Equal(t, g.String(), clone.String())
+	assert.Equal(t, g.Eval([]float64{0.5}), clone.Eval([]float64{0.5}))
+	clone.nodes[0].fn = 1 - clone.nodes[0].fn
+	assert.NotEqual(t, g.String(), clone.String())
+}
+
+func TestParsimony(t *testing.T) {
+	g := New(Config{
+		Functions: []Function{Add},
+		Terminals: []Terminal{Const(1)},
+	})()
+
+	fitness := Parsimony(func(*Genome) float32 { return 1 }, 0.01)
+	assert.InDelta(t, 1-0.01*float32(g.Size()), fitness(g), 1e-6)
+	assert.Equal(t, float32(0), Parsimony(func(*Genome) float32 { return 0 }, 1)(g))
+}
+
+func TestNoTerminals(t *testing.T) {
+	assert.Panics(t, func() {
+		New(Config{Functions: []Function{Add}})
+	})
+}
+
+func TestInvalidDepth(t *testing.T) {
+	assert.Panics(t, func() {
+		New(Config{
+			Functions:    []Function{Add},
+			Terminals:    []Terminal{Var("x", 0)},
+			MinDepth:     4,
+			MaxInitDepth: 3,
+		})
+	})
+
+	assert.Panics(t, func() {
+		New(Config{
+			Functions:    []Function{Add},
+			Terminals:    []Terminal{Var("x", 0)},
+			MaxInitDepth: 6,
+			MaxDepth:     5,
+		})
+	})
+}
+
+// evaluate evaluates the tree against x^2 + x + 1
+func evaluate(g *Genome) float32 {
+	var errors float64
+	for x := -1.0; x <= 1.0; x += 0.1 {
+		errors += math.Abs(g.Eval([]float64{x}) - (x*x + x + 1))
+	}
+
+	if math.IsNaN(errors) {
+		return 0
+	}
+	return float32(1 / (1 + errors))
+}
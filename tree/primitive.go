@@ -0,0 +1,120 @@
+// Copyright (c) Roman Atachiants and contributors. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for details.
+
+package tree
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+)
+
+// Function represents a function primitive with a fixed arity
+type Function struct {
+	Name  string                       // The name of the function
+	Arity int                          // The number of arguments
+	Eval  func(args []float64) float64 // The implementation of the function
+}
+
+// Terminal represents a terminal primitive, which is either a variable, a constant or an
+// ephemeral random constant which is generated once for every node.
+type Terminal struct {
+	Name   string         // The name of the terminal
+	index  int            // The index of the variable, or -1 for constants
+	value  float64        // The value of the constant
+	random func() float64 // The generator of the ephemeral random constant
+}
+
+// Var creates a terminal which reads the variable at the specified index
+func Var(name string, index int) Terminal {
+	return Terminal{Name: name, index: index}
+}
+
+// Const creates a terminal with a constant value
+func Const(value float64) Terminal {
+	return Terminal{
+		Name:  strconv.FormatFloat(value, 'g', -1, 64),
+		index: -1,
+		value: value,
+	}
+}
+
+// Ephemeral creates an ephemeral random constant terminal, where a new constant is generated
+// by the function every time the terminal is placed in a tree.
+func Ephemeral(generate func() float64) Terminal {
+	return Terminal{
+		Name:   "erc",
+		index:  -1,
+		random: generate,
+	}
+}
+
+// Uniform returns a generator of random constants in the [min, max) range
+func Uniform(min, max float64) func() float64 {
+	return func() float64 {
+		return min + rand.Float64()*(max-min)
+	}
+}
+
+// ---------------------------------- Functions ----------------------------------
+
+var (
+	// Add returns a + b
+	Add = Function{Name: "add", Arity: 2, Eval: func(x []float64) float64 {
+		return x[0] + x[1]
+	}}
+
+	// Sub returns a - b
+	Sub = Function{Name: "sub", Arity: 2, Eval: func(x []float64) float64 {
+		return x[0] - x[1]
+	}}
+
+	// Mul returns a * b
+	Mul = Function{Name: "mul", Arity: 2, Eval: func(x []float64) float64 {
+		return x[0] * x[1]
+	}}
+
+	// Div returns a / b, protected so that a division by zero returns 1
+	Div = Function{Name: "div", Arity: 2, Eval: func(x []float64) float64 {
+		if math.Abs(x[1]) < 1e-9 {
+			return 1
+		}
+		return x[0] / x[1]
+	}}
+
+	// Neg returns -a
+	Neg = Function{Name: "neg", Arity: 1, Eval: func(x []float64) float64 {
+		return -x[0]
+	}}
+
+	// Sin returns sin(a)
+	Sin = Function{Name: "sin", Arity: 1, Eval: func(x []float64) float64 {
+		return math.Sin(x[0])
+	}}
+
+	// Cos returns cos(a)
+	Cos = Function{Name: "cos", Arity: 1, Eval: func(x []float64) float64 {
+		return math.Cos(x[0])
+	}}
+
+	// Exp returns e^a, capped to avoid overflowing
+	Exp = Function{Name: "exp", Arity: 1, Eval: func(x []float64) float64 {
+		return math.Exp(math.Min(x[0], 100))
+	}}
+
+	// Log returns ln|a|, protected so that the logarithm of zero returns 0
+	Log = Function{Name: "log", Arity: 1, Eval: func(x []float64) float64 {
+		if math.Abs(x[0]) < 1e-9 {
+			return 0
+		}
+		return math.Log(math.Abs(x[0]))
+	}}
+
+	// If returns b if a > 0, or c otherwise
+	If = Function{Name: "if", Arity: 3, Eval: func(x []float64) float64 {
+		if x[0] > 0 {
+			return x[1]
+		}
+		return x[2]
+	}}
+)